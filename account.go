@@ -2,14 +2,15 @@ package mcgo
 
 import (
 	"bytes"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"strconv"
+	"net/url"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -18,10 +19,12 @@ func (account *MCaccount) AuthenticatedReq(method string, url string, body io.Re
 	if err != nil {
 		return nil, err
 	}
-	if account.Bearer == "" {
+	// read under the auth lock, EnableAutoRefresh may be replacing it
+	bearer := account.currentBearer()
+	if bearer == "" {
 		return nil, errors.New("account is not authenticated")
 	}
-	req.Header.Add("Authorization", "Bearer "+account.Bearer)
+	req.Header.Add("Authorization", "Bearer "+bearer)
 	req.Header.Set("Content-Type", "application/json")
 
 	return req, nil
@@ -45,17 +48,38 @@ func (r *RequestError) Error() string {
 	return r.Err.Error()
 }
 
+func (r *RequestError) Unwrap() error {
+	return r.Err
+}
+
 // represents a minecraft account
 type MCaccount struct {
 	Email             string
 	Password          string
 	SecurityQuestions []SqAnswer
-	SecurityAnswers   []string
+	SecurityAnswers   []string       // in the order the questions are loaded, AnswersByQuestion makes the pairing explicit
+	AnswersByQuestion map[int]string // security answers by Question.ID, used instead of SecurityAnswers when set
 	Bearer            string
+	ClientToken       string    // yggdrasil client token the bearer was issued to, needed by Refresh
+	MsRefreshToken    string    // microsoft oauth refresh token, needed by RefreshMsToken
+	MsClientID        string    // oauth client id MsRefreshToken was issued to, the launcher's (used by MsDeviceCodeAuth) if empty
+	ExpiresAt         time.Time // expiry of the bearer, set after every authentication, zero if unknown
+	XblToken          string    // xbox live user token of the last microsoft login, needed by FetchXboxProfile
+	XblUserHash       string    // user hash (uhs) XblToken was issued with
+	XUID              string    // xbox user id, filled in by FetchXboxProfile
+	Gamertag          string    // filled in by FetchXboxProfile
 	UUID              string
 	Username          string
 	Type              AccType
 	Authenticated     bool
+	Client            *Client // used for standard requests, DefaultClient if nil
+	Dialer            Dialer  // used to open name change connections, DefaultDialer if nil
+	SkinHistory       []SkinSnapshot
+	LastProfile       *Profile // profile seen by the last DetectSuspiciousAccess
+	Tags              []string // free form labels for organizing account pools
+	Proxy             string   // url of the proxy the account's Client and Dialer send through, informational (see ImportCSV)
+
+	auth *sync.RWMutex // guards the auth fields against background refreshes, created by authLock
 }
 
 type authenticateReqResp struct {
@@ -71,26 +95,26 @@ type authenticateReqResp struct {
 	Clienttoken string `json:"clientToken"`
 }
 
-func (account *MCaccount) authenticate() error {
-	payload := fmt.Sprintf(`{
-    "agent": {                              
-        "name": "Minecraft",                
-        "version": 1                        
-    },
-    "username": "%s",      
-    "password": "%s",
-	"requestUser": true
-}`, account.Email, account.Password)
-
-	u := bytes.NewReader([]byte(payload))
-	request, err := http.NewRequest("POST", "https://authserver.mojang.com/authenticate", u)
-	request.Header.Set("Content-Type", "application/json")
+type authenticateReqBody struct {
+	Agent struct {
+		Name    string `json:"name"`
+		Version int    `json:"version"`
+	} `json:"agent"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	ClientToken string `json:"clientToken"`
+	RequestUser bool   `json:"requestUser"`
+}
 
-	if err != nil {
-		return err
-	}
+func authenticateBody(email, password, clientToken string) authenticateReqBody {
+	body := authenticateReqBody{Username: email, Password: password, ClientToken: clientToken, RequestUser: true}
+	body.Agent.Name = "Minecraft"
+	body.Agent.Version = 1
+	return body
+}
 
-	resp, err := http.DefaultClient.Do(request)
+func (account *MCaccount) authenticate() error {
+	resp, err := account.postAuthserver("authenticate", authenticateBody(account.Email, account.Password, account.clientToken()))
 
 	if err != nil {
 		return err
@@ -110,11 +134,18 @@ func (account *MCaccount) authenticate() error {
 		}
 
 		account.Bearer = AccountInfo.Accesstoken
+		account.updateExpiry()
+		account.ClientToken = AccountInfo.Clienttoken
 		account.Username = AccountInfo.User.Username
 		account.UUID = AccountInfo.User.ID
 		return nil
 
+	} else if resp.StatusCode == 410 {
+		return ErrMigrated
 	} else if resp.StatusCode == 403 {
+		if b, _ := ioutil.ReadAll(resp.Body); bytes.Contains(b, []byte(`"Migrated"`)) {
+			return ErrMigrated
+		}
 		return errors.New("invalid email or password")
 	}
 	return errors.New("reached end of authenticate function! Shouldn't be possible. most likely 'failed to auth' status code changed")
@@ -130,90 +161,64 @@ type SqAnswer struct {
 	} `json:"question"`
 }
 
-func (account *MCaccount) loadSecurityQuestions() error {
+// Loads the security questions of a Mojang account into SecurityQuestions and returns them, empty if the account has none.
+// Answer them with SubmitSecurityAnswers, keyed by each Question.ID.
+func (account *MCaccount) GetSecurityQuestions() ([]SqAnswer, error) {
 	req, err := account.AuthenticatedReq("GET", "https://api.mojang.com/user/security/challenges", nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := account.do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("got status %v when requesting security questions", resp.Status)
+		return nil, fmt.Errorf("got status %v when requesting security questions", resp.Status)
 	}
 
-	defer resp.Body.Close()
-
 	var sqAnswers []SqAnswer
 
 	respBytes, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = json.Unmarshal(respBytes, &sqAnswers)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	account.SecurityQuestions = sqAnswers
 
-	return nil
-}
-
-type accInfoResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	return sqAnswers, nil
 }
 
 // load account information (username, uuid) into accounts attributes, if not already there. When using Mojang authentication it is not necessary to load this info, as it will be automatically loaded.
 func (account *MCaccount) LoadAccountInfo() error {
-	req, err := account.AuthenticatedReq("GET", "https://api.minecraftservices.com/minecraft/profile", nil)
-	if err != nil {
-		return err
-	}
-	resp, err := http.DefaultClient.Do(req)
-
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
-		return &RequestError{
-			StatusCode: resp.StatusCode,
-			Err:        errors.New("account does not own minecraft"),
-		}
-	}
-
-	respBytes, err := ioutil.ReadAll(resp.Body)
-
+	profile, err := account.FetchProfile()
 	if err != nil {
 		return err
 	}
 
-	var respJson accInfoResponse
-
-	json.Unmarshal(respBytes, &respJson)
-
-	account.Username = respJson.Name
-	account.UUID = respJson.ID
+	account.Username = profile.Name
+	account.UUID = profile.ID
 
 	return nil
 }
 
-func (account *MCaccount) needToAnswer() (bool, error) {
+// Returns whether the security questions have to be answered before the bearer can be used from this location.
+func (account *MCaccount) NeedsSecurityAnswers() (bool, error) {
 	req, err := account.AuthenticatedReq("GET", "https://api.mojang.com/user/security/location", nil)
 	if err != nil {
 		return false, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := account.do(req)
 
 	if err != nil {
 		return true, err
@@ -226,7 +231,7 @@ func (account *MCaccount) needToAnswer() (bool, error) {
 	if resp.StatusCode == 403 {
 		return true, nil
 	}
-	return true, fmt.Errorf("status of %v in needToAnswer not expected", resp.Status)
+	return true, fmt.Errorf("status of %v in NeedsSecurityAnswers not expected", resp.Status)
 }
 
 type submitPostJson struct {
@@ -234,16 +239,56 @@ type submitPostJson struct {
 	Answer string `json:"answer"`
 }
 
+// answers SecurityQuestions with AnswersByQuestion, or else SecurityAnswers in the same order
 func (account *MCaccount) submitAnswers() error {
+	if account.AnswersByQuestion != nil {
+		return account.SubmitSecurityAnswers(account.AnswersByQuestion)
+	}
 	if len(account.SecurityAnswers) != 3 {
 		return errors.New("not enough security question answers provided")
 	}
 	if len(account.SecurityQuestions) != 3 {
 		return errors.New("security questions not properly loaded")
 	}
-	var jsonContent []submitPostJson
+	answers := map[int]string{}
 	for i, sq := range account.SecurityQuestions {
-		jsonContent = append(jsonContent, submitPostJson{ID: sq.Answer.ID, Answer: account.SecurityAnswers[i]})
+		answers[sq.Question.ID] = account.SecurityAnswers[i]
+	}
+	return account.SubmitSecurityAnswers(answers)
+}
+
+func (account *MCaccount) securityAnswerCount() int {
+	if account.AnswersByQuestion != nil {
+		return len(account.AnswersByQuestion)
+	}
+	return len(account.SecurityAnswers)
+}
+
+// Answers the questions loaded by GetSecurityQuestions, answers are keyed by Question.ID. The pairing is checked before anything is sent:
+// every loaded question needs an answer, and answers to questions the account wasn't asked are refused.
+func (account *MCaccount) SubmitSecurityAnswers(answers map[int]string) error {
+	if len(account.SecurityQuestions) == 0 {
+		return errors.New("security questions not properly loaded")
+	}
+	asked := map[int]bool{}
+	var jsonContent []submitPostJson
+	for _, sq := range account.SecurityQuestions {
+		answer, ok := answers[sq.Question.ID]
+		if !ok {
+			return fmt.Errorf("no answer for security question %v (%q)", sq.Question.ID, sq.Question.Question)
+		}
+		asked[sq.Question.ID] = true
+		jsonContent = append(jsonContent, submitPostJson{ID: sq.Answer.ID, Answer: answer})
+	}
+	var unknown []int
+	for id := range answers {
+		if !asked[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Ints(unknown)
+		return fmt.Errorf("security answers given for questions %v, which the account wasn't asked", unknown)
 	}
 	jsonStr, err := json.Marshal(jsonContent)
 	if err != nil {
@@ -254,7 +299,7 @@ func (account *MCaccount) submitAnswers() error {
 		return err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := account.do(req)
 
 	if err != nil {
 		return err
@@ -278,7 +323,7 @@ func (account *MCaccount) MojangAuthenticate() error {
 	if err != nil {
 		return err
 	}
-	err = account.loadSecurityQuestions()
+	_, err = account.GetSecurityQuestions()
 
 	if err != nil {
 		return err
@@ -289,7 +334,7 @@ func (account *MCaccount) MojangAuthenticate() error {
 		return nil
 	}
 
-	answerNeeded, err := account.needToAnswer()
+	answerNeeded, err := account.NeedsSecurityAnswers()
 	if err != nil {
 		return err
 	}
@@ -319,65 +364,20 @@ type HasGcAppliedResp struct {
 	} `json:"details"`
 }
 
+// Returns true if the account owns minecraft but has no profile yet.
+//
+// Deprecated: HasGcApplied used to find this out by trying to create a profile named "test". It now checks OwnsMinecraft and HasProfile, use those directly.
 func (account *MCaccount) HasGcApplied() (bool, error) {
-	bodyStr := `{"profileName": "test"}`
-	req, err := account.AuthenticatedReq("POST", "https://api.minecraftservices.com/minecraft/profile", bytes.NewReader([]byte(bodyStr)))
-	if err != nil {
+	warnDeprecated("HasGcApplied", "OwnsMinecraft and HasProfile")
+	owns, err := account.OwnsMinecraft()
+	if err != nil || !owns {
 		return false, err
 	}
-
-	resp, err := http.DefaultClient.Do(req)
+	hasProfile, err := account.HasProfile()
 	if err != nil {
 		return false, err
 	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 200 {
-		return false, &RequestError{
-			StatusCode: resp.StatusCode,
-			Err:        errors.New("successfully created profile with name test.. unintended behavior, function is meant to check if gc is applied"),
-		}
-	} else if resp.StatusCode == 401 {
-		return false, &RequestError{
-			StatusCode: resp.StatusCode,
-			Err:        errors.New("received unauthorized response"),
-		}
-	} else if resp.StatusCode == 400 {
-		var respError HasGcAppliedResp
-		bodyBytes, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return false, err
-		}
-
-		err = json.Unmarshal(bodyBytes, &respError)
-		if err != nil {
-			return false, err
-		}
-
-		var hasGc bool
-
-		switch respError.Details.Status {
-		case "ALREADY_REGISTERED", "NOT_ENTITLED":
-			{
-				hasGc = false
-			}
-		case "DUPLICATE", "NOT_ALLOWED":
-			{
-				hasGc = true
-			}
-		default:
-			{
-				hasGc = false
-			}
-		}
-
-		return hasGc, nil
-
-	}
-
-	return false, fmt.Errorf("got status %v, expected 200, 401, or 400", resp.StatusCode)
-
+	return !hasProfile, nil
 }
 
 // Holds name change information for an account, the time the current account was created, it's name was most recently changed, and if it can currently change its name.
@@ -389,14 +389,13 @@ type nameChangeInfoResponse struct {
 
 // grab information on the availability of name change for this account
 func (account *MCaccount) NameChangeInfo() (nameChangeInfoResponse, error) {
-	client := &http.Client{}
 	req, err := account.AuthenticatedReq("GET", "https://api.minecraftservices.com/minecraft/profile/namechange", nil)
 
 	if err != nil {
 		return nameChangeInfoResponse{}, err
 	}
 
-	resp, err := client.Do(req)
+	resp, err := account.do(req)
 	if err != nil {
 		return nameChangeInfoResponse{}, err
 	}
@@ -430,155 +429,185 @@ func (account *MCaccount) NameChangeInfo() (nameChangeInfoResponse, error) {
 }
 
 type NameChangeReturn struct {
-	Account     MCaccount
-	Username    string
-	ChangedName bool
-	StatusCode  int
-	SendTime    time.Time
-	ReceiveTime time.Time
+	Account          MCaccount
+	Username         string
+	ChangedName      bool
+	StatusCode       int
+	DialStart        time.Time // connection started opening
+	DialEnd          time.Time // connection (including the TLS handshake) ready
+	PartialWriteTime time.Time // all but the last bytes of the payload written
+	ScheduledTime    time.Time // when the last bytes were meant to be written
+	WakeTime         time.Time // the wait for ScheduledTime ended and the last bytes were handed to the connection
+	SendTime         time.Time // last bytes of the payload written
+	ReceiveTime      time.Time // first bytes of the response read
+	ResponseHeadHash string    // hex sha256 of the response status line and headers, see Receipt
 }
 
-func (account *MCaccount) ChangeName(username string, changeTime time.Time, createProfile bool) (NameChangeReturn, error) {
-
-	var payload string
-	if createProfile {
-		data := fmt.Sprintf(`{"profileName": "%s"}`, username)
-		payload = fmt.Sprintf(
-			"POST /minecraft/profile HTTP/1.1\r\n"+
-				"Host: api.minecraftservices.com\r\n"+
-				"Authorization: Bearer %s\r\n"+
-				"Content-Type: application/json\r\n"+
-				"Content-Length: %d\r\n"+
-				"\r\n"+
-				"%s",
-			account.Bearer,
-			len(data),
-			data,
-		)
-		// credit to peet for that ^
-		// and credit to tenscape for teaching me how HTTP works lol
-	} else {
-		payload = fmt.Sprintf("PUT /minecraft/profile/name/%s HTTP/1.1\r\nHost: api.minecraftservices.com\r\nAuthorization: Bearer %s\r\n\r\n", username, account.Bearer)
-		// and that
+// How late the wait for the send time ended, delay added by the library and the go scheduler rather than the network.
+func (r NameChangeReturn) SchedulerSlip() time.Duration {
+	if r.ScheduledTime.IsZero() || r.WakeTime.IsZero() {
+		return 0
 	}
+	return r.WakeTime.Sub(r.ScheduledTime)
+}
 
-	recvd := make([]byte, 4096)
+// How long writing the last bytes took after waking up, time the payload sat queued behind the socket.
+func (r NameChangeReturn) QueueDelay() time.Duration {
+	if r.WakeTime.IsZero() || r.SendTime.IsZero() {
+		return 0
+	}
+	return r.SendTime.Sub(r.WakeTime)
+}
 
-	time.Sleep(time.Until(changeTime) - time.Second*20)
+func (account *MCaccount) ChangeName(username string, changeTime time.Time, createProfile bool) (NameChangeReturn, error) {
+	ret, err := account.changeName(username, changeTime, createProfile)
+	recordResult(ret)
+	if ret.ChangedName {
+		// keep the account in sync so monitoring doesn't report our own change as suspicious
+		account.recordOwnName(username)
+		ret.Account.Username = username
+	}
+	return ret, err
+}
 
-	conn, err := tls.Dial("tcp", "api.minecraftservices.com"+":443", nil)
-	conn.Write([]byte(payload[:len(payload)-2]))
-	if err != nil {
-		return NameChangeReturn{
-			Account:     MCaccount{},
-			Username:    username,
-			ChangedName: false,
-			StatusCode:  0,
-			SendTime:    time.Time{},
-			ReceiveTime: time.Time{},
-		}, err
+// raw request that creates a profile with username (at a drop, or for a prename account), or renames the profile to it
+func namePayload(username, bearer string, createProfile bool) string {
+	if createProfile {
+		return createPayload(username, bearer)
 	}
+	return renamePayload(username, bearer)
+}
 
-	time.Sleep(time.Until(changeTime))
+func createPayload(username, bearer string) string {
+	// marshalling quotes and escapes the name, so it can't break out of the string. A string always marshals.
+	name, _ := json.Marshal(username)
+	data := `{"profileName": ` + string(name) + `}`
+	return fmt.Sprintf(
+		"POST /minecraft/profile HTTP/1.1\r\n"+
+			"Host: api.minecraftservices.com\r\n"+
+			"Authorization: Bearer %s\r\n"+
+			"Content-Type: application/json\r\n"+
+			"Content-Length: %d\r\n"+
+			"\r\n"+
+			"%s",
+		bearer,
+		len(data),
+		data,
+	)
+	// credit to peet for that ^
+	// and credit to tenscape for teaching me how HTTP works lol
+}
 
-	conn.Write([]byte(payload[len(payload)-2:]))
-	sendTime := time.Now()
+func renamePayload(username, bearer string) string {
+	return fmt.Sprintf("PUT /minecraft/profile/name/%s HTTP/1.1\r\nHost: api.minecraftservices.com\r\nAuthorization: Bearer %s\r\n\r\n", url.PathEscape(username), bearer)
+	// and that
+}
 
-	conn.Read(recvd)
-	recvTime := time.Now()
-	conn.Close()
-	status, err := strconv.Atoi(string(recvd[9:12]))
+// sends the name change at changeTime without modifying the account, so several can run at once
+func (account *MCaccount) changeName(username string, changeTime time.Time, createProfile bool) (NameChangeReturn, error) {
+	return account.changeNameVia(account.dialer(), username, changeTime, createProfile, &cooldownCheck{})
+}
 
-	if err != nil {
-		return NameChangeReturn{
-			Account:     MCaccount{},
-			Username:    username,
-			ChangedName: false,
-			StatusCode:  0,
-			SendTime:    sendTime,
-			ReceiveTime: time.Time{},
-		}, err
+// like changeName, opening the connection with dialer. The requests of a burst share cooldown.
+func (account *MCaccount) changeNameVia(dialer Dialer, username string, changeTime time.Time, createProfile bool, cooldown *cooldownCheck) (NameChangeReturn, error) {
+	if err := account.checkWritable(); err != nil {
+		return NameChangeReturn{Username: username}, err
 	}
-
-	toRet := NameChangeReturn{
-		Account:     *account,
-		Username:    username,
-		ChangedName: status < 300,
-		StatusCode:  status,
-		SendTime:    sendTime,
-		ReceiveTime: recvTime,
+	if err := CheckName(username); err != nil {
+		return NameChangeReturn{Username: username}, err
 	}
-	return toRet, nil
-}
-func (account *MCaccount) ChangeName1(username string, changeTime time.Time, createProfile bool) (NameChangeReturn, error) {
 
-	var payload string
-	if createProfile {
-		data := fmt.Sprintf(`{"profileName": "%s"}`, username)
-		payload = fmt.Sprintf(
-			"POST /minecraft/profile HTTP/1.1\r\n"+
-				"Host: api.minecraftservices.com\r\n"+
-				"Authorization: Bearer %s\r\n"+
-				"Content-Type: application/json\r\n"+
-				"Content-Length: %d\r\n"+
-				"\r\n"+
-				"%s",
-			account.Bearer,
-			len(data),
-			data,
-		)
-		// credit to peet for that ^
-		// and credit to tenscape for teaching me how HTTP works lol
-	} else {
-		payload = fmt.Sprintf("POST /minecraft/profile HTTP/1.1\r\nHost: api.minecraftservices.com\r\nAuthorization: Bearer %s\r\n\r\n", account.Bearer)
-		// and that
+	time.Sleep(time.Until(changeTime) - time.Second*20)
+
+	// the payload is built after sleeping, so a bearer refreshed in the meantime (see KeepAlive) is used
+	bearer := account.currentBearer()
+
+	payload := namePayload(username, bearer, createProfile)
+
+	if err := account.spendBudget(); err != nil {
+		return NameChangeReturn{Username: username}, err
 	}
 
 	recvd := make([]byte, 4096)
 
-	time.Sleep(time.Until(changeTime) - time.Second*20)
-
-	conn, err := tls.Dial("tcp", "api.minecraftservices.com"+":443", nil)
-	conn.Write([]byte(payload[:len(payload)-2]))
+	dialStart := account.now()
+	conn, err := dialer.Dial("tcp", "api.minecraftservices.com"+":443")
+	dialEnd := account.now()
 	if err != nil {
 		return NameChangeReturn{
 			Account:     MCaccount{},
 			Username:    username,
 			ChangedName: false,
 			StatusCode:  0,
+			DialStart:   dialStart,
 			SendTime:    time.Time{},
 			ReceiveTime: time.Time{},
 		}, err
 	}
+	conn.Write([]byte(payload[:len(payload)-2]))
+	partialWriteTime := account.now()
 
 	time.Sleep(time.Until(changeTime))
+	wakeTime := account.now()
 
 	conn.Write([]byte(payload[len(payload)-2:]))
-	sendTime := time.Now()
+	sendTime := account.now()
 
-	conn.Read(recvd)
-	recvTime := time.Now()
+	status, recvTime, err := readStatus(conn, recvd, account.now)
 	conn.Close()
-	status, err := strconv.Atoi(string(recvd[9:12]))
 
 	if err != nil {
 		return NameChangeReturn{
-			Account:     MCaccount{},
-			Username:    username,
-			ChangedName: false,
-			StatusCode:  0,
-			SendTime:    sendTime,
-			ReceiveTime: time.Time{},
+			Account:          MCaccount{},
+			Username:         username,
+			ChangedName:      false,
+			StatusCode:       0,
+			DialStart:        dialStart,
+			DialEnd:          dialEnd,
+			PartialWriteTime: partialWriteTime,
+			ScheduledTime:    changeTime,
+			WakeTime:         wakeTime,
+			SendTime:         sendTime,
+			ReceiveTime:      recvTime,
+		}, err
+	}
+
+	if err := cooldown.notAllowed(account, status, createProfile); err != nil {
+		return NameChangeReturn{
+			Account:          account.snapshot(),
+			Username:         username,
+			ChangedName:      false,
+			StatusCode:       status,
+			DialStart:        dialStart,
+			DialEnd:          dialEnd,
+			PartialWriteTime: partialWriteTime,
+			ScheduledTime:    changeTime,
+			WakeTime:         wakeTime,
+			SendTime:         sendTime,
+			ReceiveTime:      recvTime,
+			ResponseHeadHash: hashResponseHead(recvd),
 		}, err
 	}
 
 	toRet := NameChangeReturn{
-		Account:     *account,
-		Username:    username,
-		ChangedName: status < 300,
-		StatusCode:  status,
-		SendTime:    sendTime,
-		ReceiveTime: recvTime,
+		Account:          account.snapshot(),
+		Username:         username,
+		ChangedName:      status < 300,
+		StatusCode:       status,
+		DialStart:        dialStart,
+		DialEnd:          dialEnd,
+		PartialWriteTime: partialWriteTime,
+		ScheduledTime:    changeTime,
+		WakeTime:         wakeTime,
+		SendTime:         sendTime,
+		ReceiveTime:      recvTime,
+		ResponseHeadHash: hashResponseHead(recvd),
 	}
 	return toRet, nil
 }
+
+// Deprecated: ChangeName1 always created a profile, regardless of createProfile. Use ChangeName with createProfile set to true.
+func (account *MCaccount) ChangeName1(username string, changeTime time.Time, createProfile bool) (NameChangeReturn, error) {
+	warnDeprecated("ChangeName1", "ChangeName(username, changeTime, true)")
+	return account.ChangeName(username, changeTime, true)
+}
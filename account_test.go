@@ -8,9 +8,10 @@ import (
 )
 
 func TestPrename(t *testing.T) {
-	bearer := os.Getenv("BEARER")
+	requireLive(t)
+	bearer := os.Getenv("MCGO_LIVE_BEARER")
 	if bearer == "" {
-		bearer = "TestToken"
+		t.Skip("set MCGO_LIVE_BEARER to send a real name change")
 	}
 	acc := MCaccount{Bearer: bearer}
 
@@ -0,0 +1,137 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// what secrets are replaced with by Redacted
+const redactedSecret = "[redacted]"
+
+// json form of an account, Client and Dialer are runtime state and not persisted
+type accountJSON struct {
+	Email             string         `json:"email"`
+	Password          string         `json:"password,omitempty"`
+	SecurityQuestions []SqAnswer     `json:"securityQuestions,omitempty"`
+	SecurityAnswers   []string       `json:"securityAnswers,omitempty"`
+	AnswersByQuestion map[int]string `json:"answersByQuestion,omitempty"`
+	Bearer            string         `json:"bearer,omitempty"`
+	ClientToken       string         `json:"clientToken,omitempty"`
+	MsRefreshToken    string         `json:"msRefreshToken,omitempty"`
+	MsClientID        string         `json:"msClientId,omitempty"`
+	ExpiresAt         *time.Time     `json:"expiresAt,omitempty"`
+	XblToken          string         `json:"xblToken,omitempty"`
+	XblUserHash       string         `json:"xblUserHash,omitempty"`
+	XUID              string         `json:"xuid,omitempty"`
+	Gamertag          string         `json:"gamertag,omitempty"`
+	UUID              string         `json:"uuid,omitempty"`
+	Username          string         `json:"username,omitempty"`
+	Type              AccType        `json:"type,omitempty"`
+	Authenticated     bool           `json:"authenticated,omitempty"`
+	SkinHistory       []SkinSnapshot `json:"skinHistory,omitempty"`
+	LastProfile       *Profile       `json:"lastProfile,omitempty"`
+	Tags              []string       `json:"tags,omitempty"`
+	Proxy             string         `json:"proxy,omitempty"`
+}
+
+// Encodes everything but Client and Dialer, secrets included. Marshal account.Redacted() to log an account instead.
+func (account MCaccount) MarshalJSON() ([]byte, error) {
+	v := accountJSON{
+		Email:             account.Email,
+		Password:          account.Password,
+		SecurityQuestions: account.SecurityQuestions,
+		SecurityAnswers:   account.SecurityAnswers,
+		AnswersByQuestion: account.AnswersByQuestion,
+		Bearer:            account.Bearer,
+		ClientToken:       account.ClientToken,
+		MsRefreshToken:    account.MsRefreshToken,
+		MsClientID:        account.MsClientID,
+		XblToken:          account.XblToken,
+		XblUserHash:       account.XblUserHash,
+		XUID:              account.XUID,
+		Gamertag:          account.Gamertag,
+		UUID:              account.UUID,
+		Username:          account.Username,
+		Type:              account.Type,
+		Authenticated:     account.Authenticated,
+		SkinHistory:       account.SkinHistory,
+		LastProfile:       account.LastProfile,
+		Tags:              account.Tags,
+		Proxy:             account.Proxy,
+	}
+	if !account.ExpiresAt.IsZero() {
+		v.ExpiresAt = &account.ExpiresAt
+	}
+	return json.Marshal(v)
+}
+
+// Decodes what MarshalJSON wrote. Client and Dialer are left as they are, so an account can be decoded into one set up with a transport.
+func (account *MCaccount) UnmarshalJSON(data []byte) error {
+	var v accountJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	account.Email = v.Email
+	account.Password = v.Password
+	account.SecurityQuestions = v.SecurityQuestions
+	account.SecurityAnswers = v.SecurityAnswers
+	account.AnswersByQuestion = v.AnswersByQuestion
+	account.Bearer = v.Bearer
+	account.ClientToken = v.ClientToken
+	account.MsRefreshToken = v.MsRefreshToken
+	account.MsClientID = v.MsClientID
+	account.ExpiresAt = time.Time{}
+	if v.ExpiresAt != nil {
+		account.ExpiresAt = *v.ExpiresAt
+	}
+	account.XblToken = v.XblToken
+	account.XblUserHash = v.XblUserHash
+	account.XUID = v.XUID
+	account.Gamertag = v.Gamertag
+	account.UUID = v.UUID
+	account.Username = v.Username
+	account.Type = v.Type
+	account.Authenticated = v.Authenticated
+	account.SkinHistory = v.SkinHistory
+	account.LastProfile = v.LastProfile
+	account.Tags = v.Tags
+	account.Proxy = v.Proxy
+	return nil
+}
+
+// Returns a copy with Password, Bearer, the security answers and the client, refresh and xbox tokens replaced by "[redacted]"
+// (empty ones stay empty) and the credentials of Proxy removed, safe to log or marshal. The copy can't authenticate.
+func (account MCaccount) Redacted() MCaccount {
+	redact := func(secret *string) {
+		if *secret != "" {
+			*secret = redactedSecret
+		}
+	}
+	redact(&account.Password)
+	redact(&account.Bearer)
+	redact(&account.ClientToken)
+	redact(&account.MsRefreshToken)
+	redact(&account.XblToken)
+	if proxy, err := url.Parse(account.Proxy); err != nil {
+		redact(&account.Proxy)
+	} else if proxy.User != nil {
+		proxy.User = nil
+		account.Proxy = proxy.String()
+	}
+	if account.SecurityAnswers != nil {
+		answers := make([]string, len(account.SecurityAnswers))
+		for i := range answers {
+			answers[i] = redactedSecret
+		}
+		account.SecurityAnswers = answers
+	}
+	if account.AnswersByQuestion != nil {
+		answers := map[int]string{}
+		for id := range account.AnswersByQuestion {
+			answers[id] = redactedSecret
+		}
+		account.AnswersByQuestion = answers
+	}
+	return account
+}
@@ -0,0 +1,114 @@
+package mcgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// A ban of an account from one scope.
+type Ban struct {
+	Scope         string // MULTIPLAYER or REALMS
+	ID            string
+	Reason        string
+	ReasonMessage string
+	Expires       time.Time // zero for a permanent ban
+}
+
+// Privileges and ban status of an account, from its player attributes.
+type Attributes struct {
+	ChatAllowed        bool
+	MultiplayerAllowed bool
+	RealmsAllowed      bool
+	TelemetryEnabled   bool
+	ProfanityFilterOn  bool
+	Bans               []Ban // sorted by scope
+}
+
+// returns true if the account is banned or can't play multiplayer or chat
+func (a Attributes) Restricted() bool {
+	return len(a.Bans) > 0 || !a.MultiplayerAllowed || !a.ChatAllowed
+}
+
+// Fetches the privileges (multiplayer, chat, realms) and bans of the account, so checkers can flag restricted accounts.
+// Needs an account that owns the game.
+func (account *MCaccount) Attributes() (Attributes, error) {
+	resp, err := account.playerAttributes()
+	if err != nil {
+		return Attributes{}, err
+	}
+	return resp.attributes(), nil
+}
+
+func (resp playerAttributesResponse) attributes() Attributes {
+	attributes := Attributes{
+		ChatAllowed:        resp.Privileges.OnlineChat.Enabled,
+		MultiplayerAllowed: resp.Privileges.MultiplayerServer.Enabled,
+		RealmsAllowed:      resp.Privileges.MultiplayerRealms.Enabled,
+		TelemetryEnabled:   resp.Privileges.Telemetry.Enabled,
+		ProfanityFilterOn:  resp.ProfanityFilterPreferences.ProfanityFilterOn,
+	}
+	for scope, ban := range resp.BanStatus.BannedScopes {
+		b := Ban{Scope: scope, ID: ban.BanID, Reason: ban.Reason, ReasonMessage: ban.ReasonMsg}
+		// expiries are in milliseconds
+		if ban.Expires > 0 {
+			b.Expires = time.Unix(0, ban.Expires*int64(time.Millisecond)).UTC()
+		}
+		attributes.Bans = append(attributes.Bans, b)
+	}
+	sort.Slice(attributes.Bans, func(i, j int) bool { return attributes.Bans[i].Scope < attributes.Bans[j].Scope })
+	return attributes
+}
+
+// returns whether the account's profanity filter is on
+func (account *MCaccount) ProfanityFilter() (bool, error) {
+	attributes, err := account.Attributes()
+	return attributes.ProfanityFilterOn, err
+}
+
+type profanityFilterBody struct {
+	ProfanityFilterPreferences struct {
+		ProfanityFilterOn bool `json:"profanityFilterOn"`
+	} `json:"profanityFilterPreferences"`
+}
+
+// Turns the account's profanity filter on or off and returns the updated attributes, so a fleet of accounts can be set up the same way.
+func (account *MCaccount) SetProfanityFilter(on bool) (Attributes, error) {
+	if err := account.checkWritable(); err != nil {
+		return Attributes{}, err
+	}
+
+	var payload profanityFilterBody
+	payload.ProfanityFilterPreferences.ProfanityFilterOn = on
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Attributes{}, err
+	}
+
+	req, err := account.AuthenticatedReq("POST", "https://api.minecraftservices.com/player/attributes", bytes.NewReader(body))
+	if err != nil {
+		return Attributes{}, err
+	}
+
+	resp, err := account.do(req)
+	if err != nil {
+		return Attributes{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return Attributes{}, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when setting the profanity filter", resp.Status),
+		}
+	}
+
+	var attributes playerAttributesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&attributes); err != nil {
+		return Attributes{}, err
+	}
+	return attributes.attributes(), nil
+}
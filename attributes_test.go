@@ -0,0 +1,54 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestAttributes(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	clean := newFakeAccount(srv, mcgotest.Account{Email: "clean@example.com", Name: "Clean", OwnsGame: true})
+	attributes, err := clean.Attributes()
+	if err != nil || attributes.Restricted() || !attributes.RealmsAllowed || len(attributes.Bans) != 0 {
+		t.Fatalf("err: %v | attributes: %+v | expected an unrestricted account", err, attributes)
+	}
+
+	banned := newFakeAccount(srv, mcgotest.Account{Email: "banned@example.com", Name: "Banned", OwnsGame: true, Bans: map[string]string{"REALMS": "hate speech", "MULTIPLAYER": "cheating"}})
+	attributes, err = banned.Attributes()
+	if err != nil || !attributes.Restricted() || attributes.MultiplayerAllowed || attributes.RealmsAllowed {
+		t.Fatalf("err: %v | attributes: %+v | expected a restricted account", err, attributes)
+	}
+	if len(attributes.Bans) != 2 || attributes.Bans[0].Scope != "MULTIPLAYER" || attributes.Bans[0].Reason != "cheating" || !attributes.Bans[0].Expires.IsZero() {
+		t.Fatalf("bans: %+v | expected the permanent bans sorted by scope", attributes.Bans)
+	}
+
+	if caps, err := banned.Capabilities(); err != nil || !caps.Banned || caps.ChatAllowed {
+		t.Fatalf("err: %v | capabilities: %+v | expected capabilities to report the ban", err, caps)
+	}
+}
+
+func TestSetProfanityFilter(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	account := newFakeAccount(srv, mcgotest.Account{Email: "filter@example.com", Name: "Filter", OwnsGame: true})
+	if on, err := account.ProfanityFilter(); err != nil || on {
+		t.Fatalf("err: %v | on: %v | expected the filter to start off", err, on)
+	}
+
+	attributes, err := account.SetProfanityFilter(true)
+	if err != nil || !attributes.ProfanityFilterOn {
+		t.Fatalf("err: %v | attributes: %+v | expected the updated attributes", err, attributes)
+	}
+	if on, err := account.ProfanityFilter(); err != nil || !on {
+		t.Fatalf("err: %v | on: %v | expected the filter to stay on", err, on)
+	}
+
+	account.Client.ReadOnly = true
+	if _, err := account.SetProfanityFilter(false); err != ErrReadOnly {
+		t.Fatalf("err: %v | expected read only clients to refuse", err)
+	}
+}
@@ -0,0 +1,75 @@
+package mcgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Authenticates with the method matching the account's type: Mojang for Mj, Microsoft for Ms and MsPr.
+func (account *MCaccount) Authenticate() error {
+	var err error
+	switch account.Type {
+	case Mj:
+		err = account.MojangAuthenticate()
+	case Ms, MsPr:
+		err = account.MicrosoftAuthenticate()
+	default:
+		return fmt.Errorf("can't authenticate account with type %q", account.Type)
+	}
+	if err != nil {
+		return err
+	}
+	account.Authenticated = true
+	return nil
+}
+
+// Result of an authentication running in the background.
+type AuthHandle struct {
+	Account *MCaccount
+	done    chan struct{}
+	err     error
+}
+
+// closed once authentication has finished or its context was cancelled
+func (h *AuthHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// error of the authentication, only meaningful after Done is closed
+func (h *AuthHandle) Err() error {
+	return h.err
+}
+
+// blocks until authentication finishes and returns its error
+func (h *AuthHandle) Wait() error {
+	<-h.done
+	return h.err
+}
+
+// Starts Authenticate in the background and returns immediately. Authentication runs on a copy of the account,
+// whose new token is applied only if it succeeds before ctx is done, so requests can keep using the old one meanwhile.
+// If it fails or ctx is done first the handle resolves with the error and the account is left untouched.
+func (account *MCaccount) AuthenticateAsync(ctx context.Context) *AuthHandle {
+	h := &AuthHandle{Account: account, done: make(chan struct{})}
+
+	working := account.snapshot()
+	result := make(chan error, 1)
+	go func() {
+		result <- working.Authenticate()
+	}()
+
+	go func() {
+		defer close(h.done)
+		select {
+		case err := <-result:
+			if err == nil {
+				account.applyAuth(&working)
+			}
+			h.err = err
+		case <-ctx.Done():
+			h.err = ctx.Err()
+		}
+	}()
+
+	return h
+}
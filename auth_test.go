@@ -0,0 +1,46 @@
+package mcgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestAuthenticateAsync(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "async@example.com", Password: "pw", Name: "Async", OwnsGame: true})
+	acc := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Client: &Client{HTTP: srv.HTTPClient()}}
+
+	h := acc.AuthenticateAsync(context.Background())
+	if err := h.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if !acc.Authenticated || acc.Bearer != fake.Bearer || acc.Username != "Async" {
+		t.Fatalf("account: %+v | expected authenticated account", acc)
+	}
+
+	srv.SetFault("/authenticate", mcgotest.Fault{Slow: 1, Delay: time.Millisecond * 200})
+	slow := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Client: &Client{HTTP: srv.HTTPClient()}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	if err := slow.AuthenticateAsync(ctx).Wait(); err != context.DeadlineExceeded || slow.Bearer != "" {
+		t.Fatalf("err: %v | bearer: %v | expected deadline exceeded and untouched account", err, slow.Bearer)
+	}
+
+	// a failed authentication keeps the old bearer, fields changed meanwhile survive a successful one
+	wrong := &MCaccount{Email: fake.Email, Password: "wrong", Type: Mj, Bearer: "old", Client: &Client{HTTP: srv.HTTPClient()}}
+	if err := wrong.AuthenticateAsync(context.Background()).Wait(); err == nil || wrong.Bearer != "old" {
+		t.Fatalf("err: %v | bearer: %v | expected the failed login to keep the old bearer", err, wrong.Bearer)
+	}
+
+	srv.SetFault("/authenticate", mcgotest.Fault{Slow: 1, Delay: time.Millisecond * 100})
+	h = slow.AuthenticateAsync(context.Background())
+	slow.Tags = []string{"added-meanwhile"}
+	if err := h.Wait(); err != nil || slow.Bearer != fake.Bearer || len(slow.Tags) != 1 {
+		t.Fatalf("err: %v | bearer: %v | tags: %v | expected the new bearer and the tags set meanwhile", err, slow.Bearer, slow.Tags)
+	}
+}
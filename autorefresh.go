@@ -0,0 +1,57 @@
+package mcgo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	AutoRefreshMargin = time.Minute * 10 // how long before the bearer expires EnableAutoRefresh renews it
+	AutoRefreshCheck  = time.Minute * 5  // how often bearers without a known expiry are validated, and the wait after a failed refresh
+)
+
+// Starts a goroutine that renews the bearer AutoRefreshMargin before it expires, until ctx is done, so long running processes don't hit 401s.
+// Bearers that aren't JWTs are validated every AutoRefreshCheck instead. Both are read once, when it's called. Refreshes are reported to handler, which may be nil.
+func (account *MCaccount) EnableAutoRefresh(ctx context.Context, handler EventHandler) {
+	go account.autoRefresh(ctx, handler, AutoRefreshMargin, AutoRefreshCheck)
+}
+
+func (account *MCaccount) autoRefresh(ctx context.Context, handler EventHandler, margin, check time.Duration) {
+	tried := false
+	for {
+		expires := account.TokenExpires()
+		wait := check
+		if !expires.IsZero() {
+			wait = expires.Sub(account.now()) - margin
+		}
+		// a refresh that failed or gave a bearer expiring within the margin shouldn't be retried right away
+		if tried && wait < check {
+			wait = check
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		tried = false
+		if expires.IsZero() && !account.bearerInvalid() {
+			continue
+		}
+
+		tried = true
+		err := account.reauthenticate()
+		if handler == nil {
+			continue
+		}
+		if err != nil {
+			handler(account.newEvent(EventTokenRefreshFailed, PriorityHigh, fmt.Sprintf("failed to refresh bearer: %v", err)))
+			continue
+		}
+		handler(account.newEvent(EventTokenRefreshed, PriorityNormal, "bearer refreshed"))
+	}
+}
@@ -0,0 +1,76 @@
+package mcgo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestEnableAutoRefresh(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	defer func(margin time.Duration) { AutoRefreshMargin = margin }(AutoRefreshMargin)
+	AutoRefreshMargin = time.Minute
+
+	// expires within the margin, so it should be refreshed right away
+	expiring := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(time.Second*30).Unix()))
+	fake := srv.AddAccount(mcgotest.Account{Email: "auto@example.com", Password: "pw", Bearer: expiring, ClientToken: "token", Name: "Auto", OwnsGame: true})
+	acc := &MCaccount{Email: fake.Email, Type: Mj, Bearer: expiring, ClientToken: "token", Client: &Client{HTTP: srv.HTTPClient()}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan Event, 1)
+	acc.EnableAutoRefresh(ctx, func(e Event) {
+		events <- e
+		cancel()
+	})
+
+	select {
+	case e := <-events:
+		if e.Type != EventTokenRefreshed || acc.currentBearer() == expiring {
+			t.Fatalf("event: %+v | bearer: %v | expected the expiring bearer to be refreshed", e, acc.currentBearer())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("bearer was not refreshed")
+	}
+}
+
+// run with -race: the refresh replaces the bearer while requests read it
+func TestAutoRefreshConcurrentRequests(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	defer func(margin time.Duration) { AutoRefreshMargin = margin }(AutoRefreshMargin)
+	AutoRefreshMargin = time.Minute
+
+	expiring := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(time.Second*30).Unix()))
+	srv.AddAccount(mcgotest.Account{Email: "race@example.com", Password: "pw", Bearer: expiring, ClientToken: "token", Name: "Race", OwnsGame: true})
+	acc := &MCaccount{Email: "race@example.com", Type: Mj, Bearer: expiring, ClientToken: "token", Client: &Client{HTTP: srv.HTTPClient()}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	refreshed := make(chan struct{})
+	acc.EnableAutoRefresh(ctx, func(e Event) {
+		cancel()
+		close(refreshed)
+	})
+
+	timeout := time.After(time.Second * 2)
+	for {
+		acc.FetchProfile()
+		select {
+		case <-refreshed:
+			if _, err := acc.FetchProfile(); err != nil {
+				t.Fatalf("err: %v | expected requests to use the refreshed bearer", err)
+			}
+			return
+		case <-timeout:
+			t.Fatal("bearer was not refreshed")
+		default:
+		}
+	}
+}
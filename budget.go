@@ -0,0 +1,154 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrBudgetExceeded = errors.New("the account used up its request budget for today")
+
+// Caps the requests each account sends per UTC day, so a runaway script can't get accounts flagged.
+// Set it as a Client's Budget, every request of accounts using the client (name changes included) is counted and refused with
+// ErrBudgetExceeded once the limit is reached. Counts are persisted to Path, so restarting a program doesn't reset them.
+//
+// Accounts are counted by lowercase email, or "uuid:" and their uuid without one (the uuid in the bearer if UUID isn't set),
+// which is also the key Remaining and Override take.
+type RequestBudget struct {
+	Limit int
+	Path  string // file the counts are kept in, "" keeps them in memory only
+	Clock Clock  // decides which day requests count towards, SystemClock if nil
+
+	mu   sync.Mutex
+	days map[string]*budgetDay // by budgetKey
+}
+
+type budgetDay struct {
+	Day   string `json:"day"`
+	Used  int    `json:"used"`
+	Extra int    `json:"extra"` // allowed above Limit by Override
+}
+
+// Returns a budget of limit requests per account per day, loading the counts saved at path if it exists.
+func NewRequestBudget(limit int, path string) (*RequestBudget, error) {
+	b := &RequestBudget{Limit: limit, Path: path, days: map[string]*budgetDay{}}
+	if path == "" {
+		return b, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &b.days); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func budgetDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// key the requests of account are counted under, "" if it has neither an email nor a uuid
+func budgetKey(account *MCaccount) string {
+	if key := cacheKey(account); key != "" {
+		return key
+	}
+	if claims, err := DecodeBearer(account.currentBearer()); err == nil && claims.ProfileID != "" {
+		return "uuid:" + strings.ToLower(claims.ProfileID)
+	}
+	return ""
+}
+
+func (b *RequestBudget) now() time.Time {
+	if b.Clock == nil {
+		return SystemClock.Now()
+	}
+	return b.Clock.Now()
+}
+
+// must be called with b.mu held, returns the counts of key for the current day, starting a new day if needed
+func (b *RequestBudget) day(key string) *budgetDay {
+	if b.days == nil {
+		b.days = map[string]*budgetDay{}
+	}
+	key = strings.ToLower(key)
+	date := budgetDate(b.now())
+	day, ok := b.days[key]
+	if !ok || day.Day != date {
+		day = &budgetDay{Day: date}
+		b.days[key] = day
+	}
+	return day
+}
+
+// must be called with b.mu held. Writes to a temporary file first, so a crash never leaves a corrupt file.
+func (b *RequestBudget) save() error {
+	if b.Path == "" {
+		return nil
+	}
+	data, err := json.Marshal(b.days)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(b.Path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(b.Path+".tmp", b.Path)
+}
+
+// counts a request of key, or returns ErrBudgetExceeded without counting it
+func (b *RequestBudget) spend(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	day := b.day(key)
+	if day.Used >= b.Limit+day.Extra {
+		return ErrBudgetExceeded
+	}
+	day.Used++
+	return b.save()
+}
+
+// requests the account of key has left today
+func (b *RequestBudget) Remaining(key string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	day := b.day(key)
+	if remaining := b.Limit + day.Extra - day.Used; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Allows the account of key extra requests above the limit for the rest of today.
+func (b *RequestBudget) Override(key string, extra int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.day(key).Extra += extra
+	return b.save()
+}
+
+// drops the counts of key, returns whether anything was counted
+func (b *RequestBudget) forget(key string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key = strings.ToLower(key)
+	day, ok := b.days[key]
+	if !ok {
+		return false, nil
+	}
+	delete(b.days, key)
+	return day.Used > 0 || day.Extra > 0, b.save()
+}
@@ -0,0 +1,42 @@
+package mcgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestRequestBudgetKeys(t *testing.T) {
+	clock := mcgotest.NewFakeClock(time.Date(2021, 6, 1, 23, 0, 0, 0, time.UTC))
+	budget, err := NewRequestBudget(1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget.Clock = clock
+	client := &Client{HTTP: DefaultClient.HTTP, Budget: budget}
+
+	first := &MCaccount{Bearer: "bearer-one", UUID: "UUID1", Client: client}
+	second := &MCaccount{Bearer: testBearer(`{"profiles": {"mc": "uuid2"}}`), Client: client}
+	if err := first.spendBudget(); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.spendBudget(); err != nil {
+		t.Fatalf("err: %v | expected accounts without an email not to share a budget", err)
+	}
+	if key := budgetKey(second); key != "uuid:uuid2" {
+		t.Fatalf("key: %v | expected the uuid of the bearer", key)
+	}
+	if err := first.spendBudget(); err != ErrBudgetExceeded {
+		t.Fatalf("err: %v | expected the second request of the day to exceed the budget", err)
+	}
+	if remaining := budget.Remaining("uuid:uuid1"); remaining != 0 {
+		t.Fatalf("remaining: %v | expected the budget of the uuid to be used up", remaining)
+	}
+
+	// the next day by the budget's clock, not the system's
+	clock.Advance(time.Hour * 2)
+	if remaining := budget.Remaining("uuid:uuid1"); remaining != 1 {
+		t.Fatalf("remaining: %v | expected a fresh budget on the next day of the clock", remaining)
+	}
+}
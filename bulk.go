@@ -0,0 +1,140 @@
+package mcgo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+type ErrorCategory string
+
+const (
+	CategoryAuth      ErrorCategory = "auth"      // 401 / 403
+	CategoryRateLimit ErrorCategory = "ratelimit" // 429
+	CategoryNotFound  ErrorCategory = "notfound"  // 404
+	CategoryNetwork   ErrorCategory = "network"
+	CategoryOther     ErrorCategory = "other"
+)
+
+// sorts an error into a broad category, based on its status code or type
+func Categorize(err error) ErrorCategory {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		switch reqErr.StatusCode {
+		case 401, 403:
+			return CategoryAuth
+		case 404:
+			return CategoryNotFound
+		case 429:
+			return CategoryRateLimit
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return CategoryNetwork
+	}
+	return CategoryOther
+}
+
+type BulkMode int
+
+const (
+	FailFast BulkMode = iota // stop at the first error and return it
+	SoftFail                 // keep going and return a BulkError of every failure
+)
+
+// Failures of a bulk operation run with SoftFail, Errors is keyed by the index of the failed item.
+// errors.Is and errors.As look through every failure in item order, the first match wins.
+type BulkError struct {
+	Total  int
+	Errors map[int]error
+}
+
+func (e *BulkError) Error() string {
+	counts := map[ErrorCategory]int{}
+	for _, err := range e.Errors {
+		counts[Categorize(err)]++
+	}
+	var parts []string
+	for category, n := range counts {
+		parts = append(parts, fmt.Sprintf("%v %v", n, category))
+	}
+	sort.Strings(parts)
+	return fmt.Sprintf("%v of %v operations failed (%v)", len(e.Errors), e.Total, strings.Join(parts, ", "))
+}
+
+func (e *BulkError) Unwrap() []error {
+	var errs []error
+	for _, i := range e.indexes() {
+		errs = append(errs, e.Errors[i])
+	}
+	return errs
+}
+
+// Is and As do what Unwrap() []error does on go 1.20 and later, for the go versions before it
+func (e *BulkError) Is(target error) bool {
+	for _, i := range e.indexes() {
+		if errors.Is(e.Errors[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *BulkError) As(target interface{}) bool {
+	for _, i := range e.indexes() {
+		if errors.As(e.Errors[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *BulkError) indexes() []int {
+	var indexes []int
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// failures grouped by category, each group in item order
+func (e *BulkError) ByCategory() map[ErrorCategory][]error {
+	grouped := map[ErrorCategory][]error{}
+	for _, i := range e.indexes() {
+		category := Categorize(e.Errors[i])
+		grouped[category] = append(grouped[category], e.Errors[i])
+	}
+	return grouped
+}
+
+// Runs fn on every account in order. With SoftFail failures don't stop the run, they are returned together as a *BulkError.
+func ForEach(accounts []*MCaccount, mode BulkMode, fn func(i int, account *MCaccount) error) error {
+	bulkErr := &BulkError{Total: len(accounts), Errors: map[int]error{}}
+	for i, account := range accounts {
+		if err := fn(i, account); err != nil {
+			if mode == FailFast {
+				return err
+			}
+			bulkErr.Errors[i] = err
+		}
+	}
+	if len(bulkErr.Errors) > 0 {
+		return bulkErr
+	}
+	return nil
+}
+
+// Probes Capabilities of every account, the result for a failed account is left empty.
+func CheckAll(accounts []*MCaccount, mode BulkMode) ([]Capabilities, error) {
+	caps := make([]Capabilities, len(accounts))
+	err := ForEach(accounts, mode, func(i int, account *MCaccount) error {
+		var err error
+		caps[i], err = account.Capabilities()
+		return err
+	})
+	return caps, err
+}
@@ -0,0 +1,54 @@
+package mcgo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestCheckAllSoftFail(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	accounts := []*MCaccount{
+		newFakeAccount(srv, mcgotest.Account{OwnsGame: true}),
+		{Bearer: "unknown", Client: &Client{HTTP: srv.HTTPClient()}},
+		newFakeAccount(srv, mcgotest.Account{Name: "Owned", OwnsGame: true}),
+		{},
+	}
+
+	caps, err := CheckAll(accounts, SoftFail)
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 2 {
+		t.Fatalf("err: %v | expected BulkError with 2 failures", err)
+	}
+	if !caps[0].CanCreateProfile || !caps[2].HasProfile {
+		t.Fatalf("caps: %+v | expected results for the working accounts", caps)
+	}
+	if grouped := bulkErr.ByCategory(); len(grouped[CategoryAuth]) != 1 || len(grouped[CategoryOther]) != 1 {
+		t.Fatalf("categories: %v", grouped)
+	}
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.StatusCode != 401 {
+		t.Fatalf("expected BulkError to unwrap to the 401, got %v", reqErr)
+	}
+
+	if _, err := CheckAll(accounts, FailFast); Categorize(err) != CategoryAuth {
+		t.Fatalf("err: %v | expected fail fast on the 401", err)
+	}
+}
+
+func TestBulkErrorIsAs(t *testing.T) {
+	first := &RequestError{StatusCode: 429, Err: errors.New("rate limited")}
+	bulkErr := &BulkError{Total: 3, Errors: map[int]error{2: ErrReadOnly, 0: first}}
+
+	// called directly, on go 1.20 and later errors.Is and errors.As would also get there through Unwrap
+	if !bulkErr.Is(ErrReadOnly) || bulkErr.Is(ErrBudgetExceeded) {
+		t.Fatal("expected Is to match the failures and nothing else")
+	}
+	var reqErr *RequestError
+	if !bulkErr.As(&reqErr) || reqErr != first {
+		t.Fatalf("got: %v | expected As to find the first failure", reqErr)
+	}
+}
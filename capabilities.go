@@ -0,0 +1,168 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// What an authenticated account can currently do.
+type Capabilities struct {
+	OwnsGame            bool
+	HasProfile          bool
+	CanChangeName       bool
+	CanCreateProfile    bool
+	MultiplayerAllowed  bool
+	ChatAllowed         bool
+	Banned              bool      // banned from at least one scope (multiplayer, realms)
+	NameChangeAllowedAt time.Time // zero if the name can be changed now or the account has no profile
+}
+
+type entitlementsResponse struct {
+	Items []struct {
+		Name      string `json:"name"`
+		Signature string `json:"signature"`
+	} `json:"items"`
+	Signature string `json:"signature"`
+	KeyID     string `json:"keyId"`
+}
+
+type privilege struct {
+	Enabled bool `json:"enabled"`
+}
+
+type playerAttributesResponse struct {
+	Privileges struct {
+		OnlineChat        privilege `json:"onlineChat"`
+		MultiplayerServer privilege `json:"multiplayerServer"`
+		MultiplayerRealms privilege `json:"multiplayerRealms"`
+		Telemetry         privilege `json:"telemetry"`
+	} `json:"privileges"`
+	ProfanityFilterPreferences struct {
+		ProfanityFilterOn bool `json:"profanityFilterOn"`
+	} `json:"profanityFilterPreferences"`
+	BanStatus struct {
+		BannedScopes map[string]struct {
+			BanID     string `json:"banId"`
+			Expires   int64  `json:"expires"`
+			Reason    string `json:"reason"`
+			ReasonMsg string `json:"reasonMessage"`
+		} `json:"bannedScopes"`
+	} `json:"banStatus"`
+}
+
+// privileges by their name in the attributes response
+func (attributes playerAttributesResponse) privileges() map[string]bool {
+	return map[string]bool{
+		"onlineChat":        attributes.Privileges.OnlineChat.Enabled,
+		"multiplayerServer": attributes.Privileges.MultiplayerServer.Enabled,
+		"multiplayerRealms": attributes.Privileges.MultiplayerRealms.Enabled,
+		"telemetry":         attributes.Privileges.Telemetry.Enabled,
+	}
+}
+
+// sends an authenticated GET request and decodes the json response into v, statuses >= 400 are returned as a RequestError
+func (account *MCaccount) getJSON(url string, v interface{}) error {
+	req, err := account.AuthenticatedReq("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := account.do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v on request to %v", resp.Status, url),
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(respBytes, v)
+}
+
+// Returns true if the account owns minecraft (bought or with a redeemed gift code), from its entitlements.
+func (account *MCaccount) OwnsMinecraft() (bool, error) {
+	var entitlements entitlementsResponse
+	err := account.getJSON("https://api.minecraftservices.com/entitlements/mcstore", &entitlements)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range entitlements.Items {
+		if item.Name == "game_minecraft" || item.Name == "product_minecraft" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Returns true if the account has a minecraft profile, false for accounts that still need one created.
+func (account *MCaccount) HasProfile() (bool, error) {
+	_, err := account.FetchProfile()
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode == 404 {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (account *MCaccount) playerAttributes() (playerAttributesResponse, error) {
+	var attributes playerAttributesResponse
+	err := account.getJSON("https://api.minecraftservices.com/player/attributes", &attributes)
+	return attributes, err
+}
+
+// Probes what the account can do: owns the game, has a profile, can change its name or create a profile, and whether multiplayer and chat are allowed.
+// Name change info is only requested if the account has a profile, and attributes only if it owns the game.
+func (account *MCaccount) Capabilities() (Capabilities, error) {
+	var caps Capabilities
+
+	ownsGame, err := account.OwnsMinecraft()
+	if err != nil {
+		return caps, err
+	}
+	caps.OwnsGame = ownsGame
+
+	if !ownsGame {
+		return caps, nil
+	}
+
+	hasProfile, err := account.HasProfile()
+	if err != nil {
+		return caps, err
+	}
+	if !hasProfile {
+		caps.CanCreateProfile = true
+	} else {
+		caps.HasProfile = true
+
+		info, err := account.NameChangeInfo()
+		if err != nil {
+			return caps, err
+		}
+		caps.CanChangeName = info.Namechangeallowed
+		caps.NameChangeAllowedAt = info.AllowedAt()
+	}
+
+	attributes, err := account.Attributes()
+	if err != nil {
+		return caps, err
+	}
+	caps.MultiplayerAllowed = attributes.MultiplayerAllowed
+	caps.ChatAllowed = attributes.ChatAllowed
+	caps.Banned = len(attributes.Bans) > 0
+
+	return caps, nil
+}
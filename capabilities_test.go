@@ -0,0 +1,80 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestCapabilities(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	prename := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	caps, err := prename.Capabilities()
+	if err != nil || !caps.OwnsGame || caps.HasProfile || !caps.CanCreateProfile || !caps.MultiplayerAllowed {
+		t.Fatalf("err: %v | caps: %+v | expected owned game without profile", err, caps)
+	}
+
+	named := newFakeAccount(srv, mcgotest.Account{Name: "Named", OwnsGame: true, NameChangeAllowed: true})
+	caps, err = named.Capabilities()
+	if err != nil || !caps.HasProfile || caps.CanCreateProfile || !caps.CanChangeName {
+		t.Fatalf("err: %v | caps: %+v | expected profile that can change name", err, caps)
+	}
+
+	unowned := newFakeAccount(srv, mcgotest.Account{})
+	caps, err = unowned.Capabilities()
+	if err != nil || caps != (Capabilities{}) {
+		t.Fatalf("err: %v | caps: %+v | expected no capabilities", err, caps)
+	}
+}
+
+func TestHasGcApplied(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	hasGc, err := acc.HasGcApplied()
+	if err != nil || !hasGc {
+		t.Fatalf("err: %v | hasGc: %v | expected gc applied", err, hasGc)
+	}
+	for _, req := range srv.Requests() {
+		if req.Method == "POST" {
+			t.Fatalf("HasGcApplied sent %v %v, it must not try to create a profile", req.Method, req.Path)
+		}
+	}
+}
+
+func TestOwnsMinecraftHasProfile(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		fake       mcgotest.Account
+		owns       bool
+		hasProfile bool
+	}{
+		{mcgotest.Account{}, false, false},
+		{mcgotest.Account{OwnsGame: true}, true, false},
+		{mcgotest.Account{OwnsGame: true, Name: "Owner"}, true, true},
+	} {
+		acc := newFakeAccount(srv, tc.fake)
+		owns, err := acc.OwnsMinecraft()
+		if err != nil || owns != tc.owns {
+			t.Fatalf("err: %v | owns: %v | expected %v for %+v", err, owns, tc.owns, tc.fake)
+		}
+		hasProfile, err := acc.HasProfile()
+		if err != nil || hasProfile != tc.hasProfile {
+			t.Fatalf("err: %v | has profile: %v | expected %v for %+v", err, hasProfile, tc.hasProfile, tc.fake)
+		}
+		if hasGc, err := acc.HasGcApplied(); err != nil || hasGc != (tc.owns && !tc.hasProfile) {
+			t.Fatalf("err: %v | hasGc: %v | expected it to agree with OwnsMinecraft and HasProfile", err, hasGc)
+		}
+	}
+
+	for _, req := range srv.Requests() {
+		if req.Method != "GET" {
+			t.Fatalf("sent %v %v, the checks must only read", req.Method, req.Path)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package mcgo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Kinds of verification challenges.
+const (
+	ChallengeEmail = "email" // a code was sent to the account's email
+)
+
+// A verification challenge Microsoft raised while logging in an account.
+type Challenge struct {
+	Kind   string
+	Email  string    // the account's email
+	SentAt time.Time // when the login got the challenge, the code was sent around then
+}
+
+// Answers verification challenges, returning the code that was sent. IMAPSolver reads it from the account's inbox.
+type ChallengeSolver interface {
+	Solve(ctx context.Context, challenge Challenge) (string, error)
+}
+
+// how long a ChallengeSolver gets to come up with the code
+var ChallengeTimeout = time.Minute * 5
+
+// reports whether a page of the password login asks for a code sent to the account's email
+func isEmailChallenge(page string) bool {
+	return strings.Contains(page, "Verify your email") || strings.Contains(page, "We sent a code to")
+}
+
+// gets the code of an email challenge from the options' solver
+func (o MsAuthOptions) solveChallenge(challenge Challenge) (string, error) {
+	if o.ChallengeSolver == nil {
+		return "", errors.New("microsoft asked to verify the account's email, set a ChallengeSolver in MsAuthOptions")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ChallengeTimeout)
+	defer cancel()
+	return o.ChallengeSolver.Solve(ctx, challenge)
+}
@@ -0,0 +1,113 @@
+package mcgo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// How many name changes an account can make before a point in time.
+type ChangeBudget struct {
+	Account    *MCaccount
+	Prename    bool      // the account has no profile, its first change is a profile creation
+	NextChange time.Time // when the next change is allowed, zero if allowed now
+	Available  int
+}
+
+// Returned when a plan needs more name changes than the accounts can make, Unserved are the drops no account could take.
+type ChangeBudgetError struct {
+	Required int
+	Unserved []time.Time
+}
+
+func (e *ChangeBudgetError) Error() string {
+	return fmt.Sprintf("plan needs %v name changes but %v drops can't be served by any account", e.Required, len(e.Unserved))
+}
+
+// Number of changes that fit between from and until, when the first one is allowed at nextChange.
+// Prename accounts and accounts off cooldown pass a zero nextChange.
+func ChangesAvailable(nextChange, from, until time.Time) int {
+	start := nextChange
+	if start.Before(from) {
+		start = from
+	}
+	if start.After(until) {
+		return 0
+	}
+	return 1 + int(until.Sub(start)/NameChangeCooldown)
+}
+
+// grab how many name changes the account can make from now until the given time, based on its cooldown and prename status
+func (account *MCaccount) ChangeBudget(until time.Time) (ChangeBudget, error) {
+	budget := ChangeBudget{Account: account}
+
+	_, err := account.FetchProfile()
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode == 404 {
+		budget.Prename = true
+	} else if err != nil {
+		return budget, err
+	} else {
+		budget.NextChange, err = account.NameChangeAllowedAt()
+		if err != nil {
+			return budget, err
+		}
+	}
+
+	budget.Available = ChangesAvailable(budget.NextChange, account.now(), until)
+	return budget, nil
+}
+
+// Assigns drops to accounts in time order, each drop going to an account whose cooldown is over by then.
+// Returns the drops that no account can take, an empty result means the accounts can serve every drop.
+func PlanChanges(budgets []ChangeBudget, drops []time.Time) []time.Time {
+	next := make([]time.Time, len(budgets))
+	for i, budget := range budgets {
+		next[i] = budget.NextChange
+	}
+
+	sorted := append([]time.Time(nil), drops...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	var unserved []time.Time
+	for _, drop := range sorted {
+		chosen := -1
+		for i := range next {
+			// use the account that has been free the longest
+			if !next[i].After(drop) && (chosen == -1 || next[i].Before(next[chosen])) {
+				chosen = i
+			}
+		}
+		if chosen == -1 {
+			unserved = append(unserved, drop)
+			continue
+		}
+		next[chosen] = drop.Add(NameChangeCooldown)
+	}
+	return unserved
+}
+
+// Checks that the accounts can make a name change at every drop, returning a ChangeBudgetError if they can't.
+func CheckChangeBudget(accounts []*MCaccount, drops []time.Time) ([]ChangeBudget, error) {
+	var last time.Time
+	for _, drop := range drops {
+		if drop.After(last) {
+			last = drop
+		}
+	}
+
+	var budgets []ChangeBudget
+	for _, account := range accounts {
+		budget, err := account.ChangeBudget(last)
+		if err != nil {
+			return budgets, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if unserved := PlanChanges(budgets, drops); len(unserved) > 0 {
+		return budgets, &ChangeBudgetError{Required: len(drops), Unserved: unserved}
+	}
+	return budgets, nil
+}
@@ -0,0 +1,29 @@
+package mcgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanChanges(t *testing.T) {
+	now := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	day := time.Hour * 24
+
+	budgets := []ChangeBudget{
+		{NextChange: time.Time{}},
+		{NextChange: now.Add(day * 10)},
+	}
+	drops := []time.Time{now.Add(day), now.Add(day * 2), now.Add(day * 12)}
+
+	unserved := PlanChanges(budgets, drops)
+	if len(unserved) != 1 || !unserved[0].Equal(now.Add(day*2)) {
+		t.Fatalf("unserved: %v | expected only the second drop", unserved)
+	}
+
+	if n := ChangesAvailable(time.Time{}, now, now.Add(day*61)); n != 3 {
+		t.Fatalf("changes available: %v | expected 3", n)
+	}
+	if n := ChangesAvailable(now.Add(day*100), now, now.Add(day*61)); n != 0 {
+		t.Fatalf("changes available: %v | expected 0", n)
+	}
+}
@@ -0,0 +1,120 @@
+package mcgo
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Transport settings of a Client. Bulk workloads (checkers, scanners) should raise MaxIdleConnsPerHost
+// to roughly their concurrency so connections are reused instead of handshaking for every request.
+type TransportOptions struct {
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	TLSSessionCacheSize int                                   // TLS sessions kept for resumption, 0 disables resumption
+	TLSSessionCache     tls.ClientSessionCache                // used instead of a cache of TLSSessionCacheSize if set, e.g. a PersistentSessionCache
+	Proxy               func(*http.Request) (*url.URL, error) // http.ProxyFromEnvironment if nil
+	Timeout             time.Duration                         // per request timeout, 0 means none
+	DNSCache            *DNSCache                             // DefaultDNSCache if nil
+}
+
+var DefaultTransportOptions = TransportOptions{
+	MaxIdleConnsPerHost: 16,
+	IdleConnTimeout:     time.Second * 90,
+	TLSSessionCacheSize: 64,
+	Timeout:             time.Second * 30,
+}
+
+// Client makes the standard (not timing sensitive) requests for accounts, name changes use the account's Dialer instead.
+type Client struct {
+	HTTP     *http.Client
+	Clock    Clock          // SystemClock if nil
+	Budget   *RequestBudget // no limit if nil
+	ReadOnly bool           // refuse everything that changes an account (name, profile, skin, profanity filter, gift codes) with ErrReadOnly, for analyzing pools or demos
+}
+
+var ErrReadOnly = errors.New("the client is read-only, the account was not changed")
+
+// Client used by accounts that don't set their own, and by package level functions.
+var DefaultClient = NewClient(DefaultTransportOptions)
+
+func NewClient(opts TransportOptions) *Client {
+	proxy := opts.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	dnsCache := opts.DNSCache
+	if dnsCache == nil {
+		dnsCache = DefaultDNSCache
+	}
+
+	tlsConfig := &tls.Config{}
+	if opts.TLSSessionCache != nil {
+		tlsConfig.ClientSessionCache = opts.TLSSessionCache
+	} else if opts.TLSSessionCacheSize > 0 {
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(opts.TLSSessionCacheSize)
+	}
+
+	transport := &http.Transport{
+		Proxy:               proxy,
+		DialContext:         dnsCache.DialContext,
+		TLSClientConfig:     tlsConfig,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: opts.MaxIdleConnsPerHost,
+		IdleConnTimeout:     opts.IdleConnTimeout,
+		TLSHandshakeTimeout: time.Second * 10,
+	}
+
+	return &Client{
+		HTTP: &http.Client{
+			Transport: transport,
+			Timeout:   opts.Timeout,
+		},
+	}
+}
+
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.HTTP.Do(req)
+}
+
+func (c *Client) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+func (account *MCaccount) client() *Client {
+	if account.Client != nil {
+		return account.Client
+	}
+	return DefaultClient
+}
+
+func (account *MCaccount) do(req *http.Request) (*http.Response, error) {
+	if err := account.spendBudget(); err != nil {
+		return nil, err
+	}
+	return account.client().Do(req)
+}
+
+// returns ErrReadOnly if the account's client doesn't allow changing it
+func (account *MCaccount) checkWritable() error {
+	if account.client().ReadOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+func (account *MCaccount) spendBudget() error {
+	c := account.client()
+	if c.Budget == nil {
+		return nil
+	}
+	return c.Budget.spend(budgetKey(account))
+}
@@ -0,0 +1,85 @@
+package mcgo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestNewClient(t *testing.T) {
+	c := NewClient(TransportOptions{MaxIdleConnsPerHost: 50, IdleConnTimeout: time.Minute, TLSSessionCacheSize: 8})
+	tr := c.HTTP.Transport.(*http.Transport)
+	if tr.MaxIdleConnsPerHost != 50 || tr.IdleConnTimeout != time.Minute || tr.TLSClientConfig.ClientSessionCache == nil {
+		t.Fatalf("transport not configured from options: %+v", tr)
+	}
+
+	c = NewClient(TransportOptions{})
+	if c.HTTP.Transport.(*http.Transport).TLSClientConfig.ClientSessionCache != nil {
+		t.Fatal("expected no session cache when TLSSessionCacheSize is 0")
+	}
+
+	acc := MCaccount{}
+	if acc.client() != DefaultClient {
+		t.Fatal("expected account without client to use DefaultClient")
+	}
+}
+
+// returns an account pointed at srv, added to it as fake
+func newFakeAccount(srv *mcgotest.Server, fake mcgotest.Account) *MCaccount {
+	stored := srv.AddAccount(fake)
+	return &MCaccount{
+		Email:    stored.Email,
+		Password: stored.Password,
+		Bearer:   stored.Bearer,
+		UUID:     stored.UUID,
+		Username: stored.Name,
+		Client:   &Client{HTTP: srv.HTTPClient()},
+		Dialer:   srv,
+	}
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestClientClock(t *testing.T) {
+	at := time.Date(2022, 1, 2, 3, 4, 5, 6, time.FixedZone("UTC+2", 2*60*60))
+	acc := MCaccount{Email: "clock@example.com", Client: &Client{Clock: fixedClock(at)}}
+
+	e := acc.newEvent(EventSkinChanged, PriorityNormal, "")
+	if !e.Time.Equal(at) || e.Time.Location() != time.UTC {
+		t.Fatalf("got %v | expected %v in UTC", e.Time, at)
+	}
+
+	if now := (&MCaccount{}).now(); now.Location() != time.UTC {
+		t.Fatalf("got %v | expected the system clock in UTC", now)
+	}
+}
+
+func TestClientReadOnly(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "readonly@example.com", Password: "pw", Name: "Viewer", OwnsGame: true})
+	acc.Client.ReadOnly = true
+
+	if _, err := acc.FetchProfile(); err != nil {
+		t.Fatalf("err: %v | expected reads to work", err)
+	}
+	if _, err := acc.ChangeName("Changed", time.Now(), false); err != ErrReadOnly {
+		t.Fatalf("err: %v | expected the rename to be refused", err)
+	}
+	if _, err := acc.ChangeSkin("https://example.com/skin.png", SkinOptions{}); err != ErrReadOnly {
+		t.Fatalf("err: %v | expected the skin change to be refused", err)
+	}
+	if result := acc.Burst("Changed", time.Now(), false, BurstOptions{Requests: 2}); result.Errors[0] != ErrReadOnly {
+		t.Fatalf("errors: %v | expected the burst to be refused", result.Errors)
+	}
+	if profile, _ := acc.FetchProfile(); profile.Name != "Viewer" {
+		t.Fatalf("name: %v | expected the profile to be unchanged", profile.Name)
+	}
+}
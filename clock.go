@@ -0,0 +1,34 @@
+package mcgo
+
+import "time"
+
+// Format timestamps are written in when mcgo formats them itself, time.Time already marshals to JSON in it.
+const TimeFormat = time.RFC3339Nano
+
+// Source of the timestamps put in results and events. Nodes of a distributed setup can share a synchronized clock,
+// tests can use a fixed one.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Clock used when a Client doesn't set one
+var SystemClock Clock = systemClock{}
+
+// current time of the client's clock, in UTC so timestamps from different nodes compare and merge directly
+func (c *Client) now() time.Time {
+	clock := c.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	return clock.Now().UTC()
+}
+
+func (account *MCaccount) now() time.Time {
+	return account.client().now()
+}
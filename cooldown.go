@@ -0,0 +1,74 @@
+package mcgo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Minimum time between two name changes of a profile, a newly created profile counts as its first change.
+const NameChangeCooldown = time.Hour * 24 * 30
+
+// How long a name stays reserved after its owner changes away from it, before anyone can claim it.
+const NameReleaseDelay = time.Hour * 24 * 37
+
+// Returned by rename flows when the account can't change its name yet, AllowedAt is when it will be able to.
+type NameChangeNotAllowedError struct {
+	AllowedAt time.Time
+}
+
+func (e *NameChangeNotAllowedError) Error() string {
+	return fmt.Sprintf("name change not allowed until %v", e.AllowedAt.Format(time.RFC3339))
+}
+
+// time at which the name can be changed again, zero if it can be changed now
+func (info nameChangeInfoResponse) AllowedAt() time.Time {
+	if info.Namechangeallowed {
+		return time.Time{}
+	}
+	return CooldownEnds(info.Createdat, info.Changedat)
+}
+
+// Returns when a profile created at createdAt and last renamed at changedAt can change its name again.
+func CooldownEnds(createdAt, changedAt time.Time) time.Time {
+	last := changedAt
+	if createdAt.After(last) {
+		last = createdAt
+	}
+	return last.Add(NameChangeCooldown)
+}
+
+// Returns when a name its owner changed away from at changedAt can be claimed, the drop time to snipe it at.
+func NameReleasedAt(changedAt time.Time) time.Time {
+	return changedAt.Add(NameReleaseDelay)
+}
+
+// grab the time at which this account will be allowed to change its name, zero if it is allowed now
+func (account *MCaccount) NameChangeAllowedAt() (time.Time, error) {
+	info, err := account.NameChangeInfo()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.AllowedAt(), nil
+}
+
+// Finds out if renames got their 403 for being on cooldown. The cooldown is looked up once, on the first 403, so a burst
+// of 403s doesn't send a lookup for each of them.
+type cooldownCheck struct {
+	once sync.Once
+	err  error // a *NameChangeNotAllowedError, nil if the lookup failed or the name can be changed
+}
+
+// returns a *NameChangeNotAllowedError if status is the 403 of a rename on cooldown
+func (c *cooldownCheck) notAllowed(account *MCaccount, status int, createProfile bool) error {
+	if status != 403 || createProfile {
+		return nil
+	}
+	// a 403 on rename usually means the profile is still on cooldown (e.g. created recently), find out until when
+	c.once.Do(func() {
+		if allowedAt, err := account.NameChangeAllowedAt(); err == nil && !allowedAt.IsZero() {
+			c.err = &NameChangeNotAllowedError{AllowedAt: allowedAt}
+		}
+	})
+	return c.err
+}
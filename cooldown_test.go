@@ -0,0 +1,113 @@
+package mcgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestAllowedAt(t *testing.T) {
+	created := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	info := nameChangeInfoResponse{Createdat: created, Changedat: created}
+	if got := info.AllowedAt(); !got.Equal(created.Add(NameChangeCooldown)) {
+		t.Fatalf("allowed at: %v | expected: %v", got, created.Add(NameChangeCooldown))
+	}
+
+	changed := created.Add(time.Hour * 24 * 100)
+	info = nameChangeInfoResponse{Createdat: created, Changedat: changed}
+	if got := info.AllowedAt(); !got.Equal(changed.Add(NameChangeCooldown)) {
+		t.Fatalf("allowed at: %v | expected: %v", got, changed.Add(NameChangeCooldown))
+	}
+
+	info.Namechangeallowed = true
+	if got := info.AllowedAt(); !got.IsZero() {
+		t.Fatalf("allowed at: %v | expected zero time", got)
+	}
+}
+
+func TestChangeNameCooldown(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	created := time.Now().Add(-time.Hour * 24)
+	acc := newFakeAccount(srv, mcgotest.Account{Name: "NewProfile", OwnsGame: true, CreatedAt: created, ChangedAt: created})
+
+	ret, err := acc.ChangeName("Renamed", time.Now(), false)
+	var cooldownErr *NameChangeNotAllowedError
+	if !errors.As(err, &cooldownErr) || !cooldownErr.AllowedAt.Equal(created.Add(NameChangeCooldown)) {
+		t.Fatalf("err: %v | expected NameChangeNotAllowedError allowing at %v", err, created.Add(NameChangeCooldown))
+	}
+	if ret.StatusCode != 403 || ret.ChangedName {
+		t.Fatalf("return: %+v | expected failed 403", ret)
+	}
+}
+
+func TestCooldownTimeTravel(t *testing.T) {
+	if mcgotest.NameChangeCooldown != NameChangeCooldown || mcgotest.NameReleaseDelay != NameReleaseDelay {
+		t.Fatal("mcgotest enforces different cooldowns than mcgo")
+	}
+
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock := mcgotest.NewFakeClock(start)
+	srv.SetClock(clock)
+
+	acc := newFakeAccount(srv, mcgotest.Account{Name: "Traveler", OwnsGame: true, CreatedAt: start, ChangedAt: start})
+	acc.Client.Clock = clock
+	sniper := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+
+	budget, err := acc.ChangeBudget(start.Add(time.Hour * 24 * 61))
+	if err != nil || !budget.NextChange.Equal(CooldownEnds(start, start)) || budget.Available != 2 {
+		t.Fatalf("err: %v | budget: %+v | expected 2 changes, the first at the end of the cooldown", err, budget)
+	}
+
+	if _, err := acc.ChangeName("Arrived", time.Now(), false); err == nil {
+		t.Fatal("expected the change to be refused during the cooldown")
+	}
+
+	clock.Advance(NameChangeCooldown)
+	if ret, err := acc.ChangeName("Arrived", time.Now(), false); err != nil || !ret.ChangedName {
+		t.Fatalf("err: %v | return: %+v | expected the change to go through after the cooldown", err, ret)
+	}
+	released := NameReleasedAt(clock.Now())
+
+	if ret, _ := sniper.ChangeName("Traveler", time.Now(), true); ret.ChangedName {
+		t.Fatal("expected the old name to be held")
+	}
+
+	clock.Set(released)
+	if ret, err := sniper.ChangeName("Traveler", time.Now(), true); err != nil || !ret.ChangedName {
+		t.Fatalf("err: %v | return: %+v | expected the old name to be claimable once released", err, ret)
+	}
+}
+
+func TestBurstCooldownLookedUpOnce(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	created := time.Now().Add(-time.Hour * 24)
+	acc := newFakeAccount(srv, mcgotest.Account{Name: "NewProfile", OwnsGame: true, CreatedAt: created, ChangedAt: created})
+
+	result := acc.Burst("Renamed", time.Now().Add(time.Millisecond*50), false, BurstOptions{Requests: 4, Spread: time.Millisecond * 20})
+	for i, err := range result.Errors {
+		var cooldownErr *NameChangeNotAllowedError
+		if !errors.As(err, &cooldownErr) {
+			t.Fatalf("err: %v | expected request %v to report the cooldown", err, i)
+		}
+	}
+
+	lookups := 0
+	for _, req := range srv.Requests() {
+		if req.Path == "/minecraft/profile/namechange" {
+			lookups++
+		}
+	}
+	if lookups != 1 {
+		t.Fatalf("lookups: %v | expected the cooldown to be looked up once for the whole burst", lookups)
+	}
+}
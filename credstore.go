@@ -0,0 +1,85 @@
+package mcgo
+
+import "errors"
+
+var ErrCredentialNotFound = errors.New("no credential is stored under this key")
+
+var errNoCredentialKey = errors.New("the account has neither an email nor a uuid to store credentials under")
+
+// Keeps the secrets of accounts out of env vars and plaintext files. Keychain (keychain build tag) stores them in the OS keychain.
+type CredentialStore interface {
+	Get(key string) (string, error) // ErrCredentialNotFound if nothing is stored under key
+	Set(key, secret string) error
+	Delete(key string) error // deleting a missing key is not an error
+}
+
+// key the secret field of account is stored under, e.g. "me@example.com:password" or "uuid:<uuid>:password" for accounts without an email,
+// "" if the account has neither
+func CredentialKey(account *MCaccount, field string) string {
+	key := cacheKey(account)
+	if key == "" {
+		return ""
+	}
+	return key + ":" + field
+}
+
+// secret fields of an account kept in a CredentialStore
+func (account *MCaccount) credentialFields() map[string]*string {
+	return map[string]*string{
+		"password":       &account.Password,
+		"msRefreshToken": &account.MsRefreshToken,
+	}
+}
+
+// Fills Password and MsRefreshToken from store, fields without a stored credential are left as they are.
+func (account *MCaccount) LoadCredentials(store CredentialStore) error {
+	current := account.snapshot()
+	if CredentialKey(&current, "") == "" {
+		return errNoCredentialKey
+	}
+
+	secrets := map[string]string{}
+	for field := range current.credentialFields() {
+		secret, err := store.Get(CredentialKey(&current, field))
+		if err == ErrCredentialNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		secrets[field] = secret
+	}
+
+	// KeepAlive rotates the refresh token under the same lock
+	lock := account.authLock()
+	lock.Lock()
+	defer lock.Unlock()
+	fields := account.credentialFields()
+	for field, secret := range secrets {
+		*fields[field] = secret
+	}
+	return nil
+}
+
+// Saves Password and MsRefreshToken to store, deleting the stored ones of empty fields.
+// Call it again after RefreshMsToken, microsoft rotates refresh tokens.
+func (account *MCaccount) StoreCredentials(store CredentialStore) error {
+	current := account.snapshot()
+	if CredentialKey(&current, "") == "" {
+		return errNoCredentialKey
+	}
+
+	for field, value := range current.credentialFields() {
+		key := CredentialKey(&current, field)
+		var err error
+		if *value == "" {
+			err = store.Delete(key)
+		} else {
+			err = store.Set(key, *value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
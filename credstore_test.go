@@ -0,0 +1,77 @@
+package mcgo
+
+import "testing"
+
+type mapStore map[string]string
+
+func (s mapStore) Get(key string) (string, error) {
+	secret, ok := s[key]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (s mapStore) Set(key, secret string) error {
+	s[key] = secret
+	return nil
+}
+
+func (s mapStore) Delete(key string) error {
+	delete(s, key)
+	return nil
+}
+
+func TestCredentialStore(t *testing.T) {
+	store := mapStore{"stale@example.com:password": "old"}
+	acc := &MCaccount{Email: "Stale@example.com", MsRefreshToken: "refresh"}
+	if err := acc.StoreCredentials(store); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store["stale@example.com:password"]; ok || store["stale@example.com:msRefreshToken"] != "refresh" {
+		t.Fatalf("store: %v | expected the refresh token stored and the empty password deleted", store)
+	}
+
+	store["stale@example.com:password"] = "pw"
+	loaded := &MCaccount{Email: "stale@example.com", Password: "unset"}
+	if err := loaded.LoadCredentials(store); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Password != "pw" || loaded.MsRefreshToken != "refresh" {
+		t.Fatalf("account: %+v | expected the stored credentials to be loaded", loaded)
+	}
+
+	other := &MCaccount{Email: "other@example.com", Password: "own"}
+	if err := other.LoadCredentials(store); err != nil || other.Password != "own" {
+		t.Fatalf("err: %v | password: %v | expected fields without a credential to be kept", err, other.Password)
+	}
+}
+
+func TestCredentialStoreConcurrentRotation(t *testing.T) {
+	store := mapStore{}
+	acc := &MCaccount{Email: "rotating@example.com", MsRefreshToken: "first"}
+
+	// a KeepAlive rotating the refresh token meanwhile, for the race detector
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			from := acc.snapshot()
+			from.MsRefreshToken = "rotated"
+			acc.applyAuth(&from)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if err := acc.StoreCredentials(store); err != nil {
+			t.Fatal(err)
+		}
+		if err := acc.LoadCredentials(store); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+
+	if token := store["rotating@example.com:msRefreshToken"]; token != "first" && token != "rotated" {
+		t.Fatalf("token: %q | expected a whole refresh token to be stored", token)
+	}
+}
@@ -0,0 +1,184 @@
+package mcgo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Header names of the columns ImportCSV reads each field from, matched case insensitively. Empty names are not read.
+type CSVColumns struct {
+	Email    string
+	Password string
+	Type     string
+	Proxy    string
+	Tags     string
+}
+
+var DefaultCSVColumns = CSVColumns{
+	Email:    "email",
+	Password: "password",
+	Type:     "type",
+	Proxy:    "proxy",
+	Tags:     "tags",
+}
+
+// separates the tags in a tags cell
+const CSVTagSeparator = ";"
+
+// A row ImportCSV skipped. Row is the line number in the file (the header is row 1).
+type RowError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %v: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %v, column %v: %v", e.Row, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Returned by ImportCSV when some rows were invalid, the accounts of the other rows are still returned.
+type ImportError struct {
+	Rows []*RowError
+}
+
+func (e *ImportError) Error() string {
+	if len(e.Rows) == 1 {
+		return e.Rows[0].Error()
+	}
+	return fmt.Sprintf("%v invalid rows, first: %v", len(e.Rows), e.Rows[0])
+}
+
+// parses an account type case insensitively, empty means Mj
+func parseAccType(s string) (AccType, error) {
+	switch t := AccType(strings.ToLower(s)); t {
+	case "":
+		return Mj, nil
+	case Mj, Ms, MsPr:
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown account type %q", s)
+}
+
+// returns a Client with the default transport options sending through proxy
+func proxyClient(proxy string) (*Client, error) {
+	proxyURL, err := parseProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	opts := DefaultTransportOptions
+	opts.Proxy = http.ProxyURL(proxyURL)
+	return NewClient(opts), nil
+}
+
+// Reads accounts from a CSV file with a header row, taking each field from the column named in columns.
+// Accounts with a proxy get a Client and a Dialer sending through it, accounts sharing a proxy share them.
+// Invalid rows are skipped and reported in an *ImportError, other errors (unreadable csv, missing email column) stop the import.
+func ImportCSV(r io.Reader, columns CSVColumns) ([]*MCaccount, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	column := func(name string) int {
+		if name == "" {
+			return -1
+		}
+		if i, ok := index[strings.ToLower(name)]; ok {
+			return i
+		}
+		return -1
+	}
+
+	emailCol, passwordCol, typeCol, proxyCol, tagsCol := column(columns.Email), column(columns.Password), column(columns.Type), column(columns.Proxy), column(columns.Tags)
+	if emailCol < 0 {
+		return nil, fmt.Errorf("csv has no %v column", columns.Email)
+	}
+
+	clients := map[string]*Client{}
+	dialers := map[string]Dialer{}
+	var accounts []*MCaccount
+	var rowErrs []*RowError
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cell := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		account := &MCaccount{
+			Email:    cell(emailCol),
+			Password: cell(passwordCol),
+			Proxy:    cell(proxyCol),
+		}
+
+		if account.Email == "" {
+			rowErrs = append(rowErrs, &RowError{Row: row, Column: columns.Email, Err: errors.New("email is empty")})
+			continue
+		}
+
+		account.Type, err = parseAccType(cell(typeCol))
+		if err != nil {
+			rowErrs = append(rowErrs, &RowError{Row: row, Column: columns.Type, Err: err})
+			continue
+		}
+
+		if tags := cell(tagsCol); tags != "" {
+			for _, tag := range strings.Split(tags, CSVTagSeparator) {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					account.Tags = append(account.Tags, tag)
+				}
+			}
+		}
+
+		if account.Proxy != "" {
+			client, ok := clients[account.Proxy]
+			if !ok {
+				client, err = proxyClient(account.Proxy)
+				if err != nil {
+					rowErrs = append(rowErrs, &RowError{Row: row, Column: columns.Proxy, Err: err})
+					continue
+				}
+				clients[account.Proxy] = client
+				dialers[account.Proxy], _ = NewProxyDialer(account.Proxy)
+			}
+			account.Client = client
+			account.Dialer = dialers[account.Proxy]
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	if len(rowErrs) > 0 {
+		return accounts, &ImportError{Rows: rowErrs}
+	}
+	return accounts, nil
+}
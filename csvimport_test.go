@@ -0,0 +1,38 @@
+package mcgo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestImportCSV(t *testing.T) {
+	data := "Mail,Pass,Kind,Proxy,Labels\n" +
+		"a@example.com,pw,ms,http://1.2.3.4:8080,og; main\n" +
+		"b@example.com,pw,,http://1.2.3.4:8080,\n" +
+		",pw,mj,,\n" +
+		"c@example.com,pw,xbox,,\n"
+
+	columns := CSVColumns{Email: "mail", Password: "pass", Type: "kind", Proxy: "proxy", Tags: "labels"}
+	accounts, err := ImportCSV(strings.NewReader(data), columns)
+
+	var importErr *ImportError
+	if !errors.As(err, &importErr) || len(importErr.Rows) != 2 || importErr.Rows[0].Row != 4 || importErr.Rows[1].Column != "kind" {
+		t.Fatalf("err: %v | expected rows 4 and 5 to be invalid", err)
+	}
+
+	if len(accounts) != 2 {
+		t.Fatalf("accounts: %v | expected 2 valid rows", len(accounts))
+	}
+	a, b := accounts[0], accounts[1]
+	if a.Type != Ms || b.Type != Mj || len(a.Tags) != 2 || a.Tags[1] != "main" {
+		t.Fatalf("a: %+v | b: %+v | expected types and tags from the mapped columns", a, b)
+	}
+	if a.Client == nil || a.Client != b.Client || a.Dialer == nil || a.Dialer != b.Dialer {
+		t.Fatal("expected accounts with the same proxy to share a client and dialer")
+	}
+
+	if _, err := ImportCSV(strings.NewReader("user,pass\n"), DefaultCSVColumns); err == nil {
+		t.Fatal("expected an error without an email column")
+	}
+}
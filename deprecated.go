@@ -0,0 +1,16 @@
+package mcgo
+
+import (
+	"log"
+	"sync"
+)
+
+var deprecationWarnings sync.Map
+
+// logs that name is deprecated, once per process
+func warnDeprecated(name, replacement string) {
+	if _, warned := deprecationWarnings.LoadOrStore(name, true); warned {
+		return
+	}
+	log.Printf("mcgo: %v is deprecated and will be removed in v2, use %v instead", name, replacement)
+}
@@ -0,0 +1,237 @@
+package mcgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// client id of the minecraft launcher, allowed to request xbox live scopes with the device code flow
+const msDeviceCodeClientID = "00000000402b5328"
+
+const msDeviceCodeScope = "service::user.auth.xboxlive.com::MBI_SSL"
+
+// scope Azure apps request xbox live access with, offline_access to get a refresh token
+const msAppScope = "XboxLive.signin offline_access"
+
+// Azure app to log in with instead of the minecraft launcher, for organizations with their own registered app.
+// Empty fields use the launcher's values, the scope defaults to msAppScope when ClientID is set.
+type MsAuthOptions struct {
+	ClientID    string
+	RedirectURI string // only used by MicrosoftAuthenticate, the device code flow has no redirect
+	Scope       string
+
+	// answer the 2fa challenge of MicrosoftAuthenticate for accounts with an authenticator app
+	TOTPSecret string                 // base32 secret the authenticator was set up with
+	TOTPCode   func() (string, error) // returns the current code, used instead of TOTPSecret if set
+
+	ChallengeSolver ChallengeSolver // answers email verification challenges of MicrosoftAuthenticate
+}
+
+// merges opts over the launcher's client id and scope for the flow
+func msAuthOptions(opts []MsAuthOptions, launcherClientID string) MsAuthOptions {
+	var o MsAuthOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.ClientID == "" {
+		o.ClientID = launcherClientID
+	}
+	if o.Scope == "" {
+		o.Scope = msScope(o.ClientID)
+	}
+	if o.RedirectURI == "" {
+		o.RedirectURI = "https://login.live.com/oauth20_desktop.srf"
+	}
+	return o
+}
+
+func isLauncherClient(clientID string) bool {
+	return clientID == msDeviceCodeClientID || clientID == msPasswordClientID
+}
+
+func msScope(clientID string) string {
+	if isLauncherClient(clientID) {
+		return msDeviceCodeScope
+	}
+	return msAppScope
+}
+
+// access tokens of Azure apps are only accepted as RPS tickets with a "d=" prefix
+func rpsTicket(clientID, accessToken string) string {
+	if isLauncherClient(clientID) {
+		return accessToken
+	}
+	return "d=" + accessToken
+}
+
+// What the user has to do to complete a device code login: open VerificationURI on any device and enter UserCode.
+type DeviceCode struct {
+	UserCode        string
+	VerificationURI string
+	ExpiresIn       time.Duration
+}
+
+type deviceCodeResponse struct {
+	UserCode        string `json:"user_code"`
+	DeviceCode      string `json:"device_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+var ErrDeviceCodeExpired = errors.New("the device code expired before the login was completed")
+
+// posts a form to login.live.com and decodes the json response into v
+func (account *MCaccount) postLiveForm(endpoint string, form url.Values, v interface{}) error {
+	resp, err := account.client().HTTP.Post(endpoint, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// token polling reports pending logins with a 400 and an error field, so only statuses without a json body are errors
+	if err := json.Unmarshal(respBytes, v); err != nil {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v from %v", resp.Status, endpoint),
+		}
+	}
+	return nil
+}
+
+// Authenticates a microsoft account with the device code flow, no password needed: prompt is called with a code the owner of the
+// account enters at the verification url (on any device), then the login is polled for until it's completed, ctx is done or the code expires.
+// opts can name your own Azure app to log in with.
+func (account *MCaccount) MsDeviceCodeAuth(ctx context.Context, prompt func(DeviceCode), opts ...MsAuthOptions) error {
+	o := msAuthOptions(opts, msDeviceCodeClientID)
+
+	var code deviceCodeResponse
+	err := account.postLiveForm("https://login.live.com/oauth20_connect.srf", url.Values{
+		"client_id":     {o.ClientID},
+		"scope":         {o.Scope},
+		"response_type": {"device_code"},
+	}, &code)
+	if err != nil {
+		return err
+	}
+
+	prompt(DeviceCode{
+		UserCode:        code.UserCode,
+		VerificationURI: code.VerificationURI,
+		ExpiresIn:       time.Duration(code.ExpiresIn) * time.Second,
+	})
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = time.Second * 5
+	}
+	expires := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var token deviceTokenResponse
+		err := account.postLiveForm("https://login.live.com/oauth20_token.srf", url.Values{
+			"client_id":   {o.ClientID},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {code.DeviceCode},
+		}, &token)
+		if err != nil {
+			return err
+		}
+
+		switch token.Error {
+		case "":
+		case "authorization_pending":
+			if time.Now().After(expires) {
+				return ErrDeviceCodeExpired
+			}
+			continue
+		case "slow_down":
+			interval += time.Second * 5
+			continue
+		case "expired_token":
+			return ErrDeviceCodeExpired
+		default:
+			return fmt.Errorf("device code login failed: %v", token.Error)
+		}
+
+		return account.completeMsLogin(o.ClientID, token)
+	}
+}
+
+// logs in to minecraft with the live.com tokens of an oauth login by clientID, keeping the refresh token for RefreshMsToken
+func (account *MCaccount) completeMsLogin(clientID string, token deviceTokenResponse) error {
+	if err := account.loginWithRpsTicket(account.client().HTTP, rpsTicket(clientID, token.AccessToken)); err != nil {
+		return err
+	}
+
+	account.MsRefreshToken = token.RefreshToken
+	account.MsClientID = clientID
+	if err := account.loadIdentity(); err != nil {
+		return err
+	}
+	account.Authenticated = true
+	return nil
+}
+
+var ErrNoMsRefreshToken = errors.New("account has no microsoft refresh token, log in with MsDeviceCodeAuth first")
+
+// Exchanges the account's MsRefreshToken for a new minecraft bearer without any interaction, so accounts logged in long before a drop
+// stay usable. Microsoft rotates refresh tokens, the new one replaces MsRefreshToken.
+func (account *MCaccount) RefreshMsToken() error {
+	if account.MsRefreshToken == "" {
+		return ErrNoMsRefreshToken
+	}
+
+	clientID := account.MsClientID
+	if clientID == "" {
+		clientID = msDeviceCodeClientID
+	}
+
+	var token deviceTokenResponse
+	err := account.postLiveForm("https://login.live.com/oauth20_token.srf", url.Values{
+		"client_id":     {clientID},
+		"grant_type":    {"refresh_token"},
+		"scope":         {msScope(clientID)},
+		"refresh_token": {account.MsRefreshToken},
+	}, &token)
+	if err != nil {
+		return err
+	}
+	if token.Error != "" {
+		return fmt.Errorf("refreshing microsoft token failed: %v", token.Error)
+	}
+
+	if err := account.loginWithRpsTicket(account.client().HTTP, rpsTicket(clientID, token.AccessToken)); err != nil {
+		return err
+	}
+
+	if token.RefreshToken != "" {
+		account.MsRefreshToken = token.RefreshToken
+	}
+	return account.loadIdentity()
+}
@@ -0,0 +1,113 @@
+package mcgo
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestMsDeviceCodeAuth(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "device@example.com", Name: "Device", OwnsGame: true})
+	acc := &MCaccount{Type: MsPr, Client: &Client{HTTP: srv.HTTPClient()}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	err := acc.MsDeviceCodeAuth(ctx, func(code DeviceCode) {
+		if code.UserCode == "" || code.VerificationURI == "" {
+			t.Errorf("code: %+v | expected a user code and url", code)
+		}
+		srv.ApproveDeviceCode(code.UserCode, fake.Email)
+	})
+	if err != nil || acc.Bearer != fake.Bearer || !acc.Authenticated || acc.UUID != fake.UUID || acc.Username != "Device" {
+		t.Fatalf("err: %v | bearer: %v | expected the account's bearer", err, acc.Bearer)
+	}
+}
+
+func TestMsDeviceCodeAuthOwnApp(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "org@example.com", Name: "Org", OwnsGame: true})
+	acc := &MCaccount{Type: MsPr, Client: &Client{HTTP: srv.HTTPClient()}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	err := acc.MsDeviceCodeAuth(ctx, func(code DeviceCode) {
+		srv.ApproveDeviceCode(code.UserCode, fake.Email)
+	}, MsAuthOptions{ClientID: "my-azure-app"})
+	if err != nil || acc.Bearer != fake.Bearer || acc.MsClientID != "my-azure-app" {
+		t.Fatalf("err: %v | client id: %v | expected a login with the own app", err, acc.MsClientID)
+	}
+
+	for _, req := range srv.Requests() {
+		switch req.Path {
+		case "/oauth20_connect.srf":
+			form, _ := url.ParseQuery(string(req.Body))
+			if form.Get("client_id") != "my-azure-app" || form.Get("scope") != msAppScope {
+				t.Fatalf("form: %v | expected the own client id and app scope", form)
+			}
+		case "/user/authenticate":
+			if !strings.Contains(string(req.Body), `"d=msa:`) {
+				t.Fatalf("body: %s | expected a d= prefixed ticket", req.Body)
+			}
+		}
+	}
+}
+
+func TestXboxExchange(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "own-app@example.com", Name: "OwnApp", OwnsGame: true})
+	client := srv.HTTPClient()
+
+	xbl, uhs, err := ExchangeRpsForXbl(client, "msa:"+fake.Email)
+	if err != nil || xbl == "" || uhs == "" {
+		t.Fatalf("err: %v | xbl: %v | uhs: %v | expected an xbl token and user hash", err, xbl, uhs)
+	}
+	xsts, err := ExchangeXblForXsts(client, xbl)
+	if err != nil {
+		t.Fatalf("err: %v | expected an xsts token", err)
+	}
+	bearer, err := LoginWithXbox(client, uhs, xsts)
+	if err != nil || bearer != fake.Bearer {
+		t.Fatalf("err: %v | bearer: %v | expected the account's bearer", err, bearer)
+	}
+
+	srv.SetFault("/xsts/authorize", mcgotest.Fault{RateLimit: 1})
+	var reqErr *RequestError
+	if xsts, err := ExchangeXblForXsts(client, xbl); !errors.As(err, &reqErr) || reqErr.StatusCode != 429 || xsts != "" {
+		t.Fatalf("err: %v | xsts: %v | expected a rate limit to be a RequestError", err, xsts)
+	}
+	srv.SetFault("/xsts/authorize", mcgotest.Fault{Malformed: 1})
+	if xsts, err := ExchangeXblForXsts(client, xbl); err == nil || xsts != "" {
+		t.Fatalf("err: %v | xsts: %v | expected a malformed response to be an error", err, xsts)
+	}
+}
+
+func TestRefreshMsToken(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "silent@example.com", Name: "Silent", OwnsGame: true})
+	acc := &MCaccount{Type: MsPr, Client: &Client{HTTP: srv.HTTPClient()}}
+
+	if err := acc.RefreshMsToken(); err != ErrNoMsRefreshToken {
+		t.Fatalf("err: %v | expected ErrNoMsRefreshToken", err)
+	}
+
+	acc.MsRefreshToken = "refresh:" + fake.Email
+	if err := acc.RefreshMsToken(); err != nil || acc.Bearer != fake.Bearer || acc.Username != "Silent" {
+		t.Fatalf("err: %v | bearer: %v | expected the account's bearer", err, acc.Bearer)
+	}
+}
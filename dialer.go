@@ -0,0 +1,75 @@
+package mcgo
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+)
+
+// Opens the connections that name change payloads are written to. The returned connection must already speak
+// HTTP/1.1 to api.minecraftservices.com (so usually it is TLS wrapped), mcgo only handles the payload and its timing.
+type Dialer interface {
+	Dial(network, addr string) (net.Conn, error)
+}
+
+type tlsDialer struct {
+	sessions tls.ClientSessionCache
+}
+
+func (d tlsDialer) Dial(network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := DefaultDNSCache.DialContext(context.Background(), network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := tls.Client(raw, &tls.Config{ServerName: host, ClientSessionCache: d.sessions})
+	if err := conn.Handshake(); err != nil {
+		raw.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Dialer used by accounts that don't set their own.
+var DefaultDialer Dialer = tlsDialer{}
+
+// Returns a Dialer like DefaultDialer that resumes TLS sessions kept in sessions (e.g. a PersistentSessionCache),
+// saving most of the handshake on connections after the first.
+func NewTLSDialer(sessions tls.ClientSessionCache) Dialer {
+	return tlsDialer{sessions: sessions}
+}
+
+type connDialer struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (d *connDialer) Dial(network, addr string) (net.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.conn == nil {
+		return nil, errors.New("pre-built connection was already used")
+	}
+	conn := d.conn
+	d.conn = nil
+	return conn, nil
+}
+
+// Returns a Dialer that hands out conn once, for users that build their own connection (tunnels, custom TLS stacks).
+func DialerFromConn(conn net.Conn) Dialer {
+	return &connDialer{conn: conn}
+}
+
+func (account *MCaccount) dialer() Dialer {
+	if account.Dialer != nil {
+		return account.Dialer
+	}
+	return DefaultDialer
+}
@@ -0,0 +1,65 @@
+//go:build utls
+// +build utls
+
+package mcgo
+
+import (
+	"context"
+	"net"
+
+	utls "github.com/refraction-networking/utls"
+)
+
+// Dialer that sends a browser-like TLS ClientHello (via uTLS) instead of Go's, for users hitting fingerprint based filtering.
+// Only available when building with the utls build tag. Dial can also be used as an http.Transport's DialTLS.
+type UTLSDialer struct {
+	HelloID utls.ClientHelloID // fingerprint to mimic, utls.HelloChrome_Auto if empty
+
+	config *utls.Config // base of the handshake config, ServerName is filled in. Verifies against the system roots if nil.
+}
+
+func (d UTLSDialer) Dial(network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	helloID := d.HelloID
+	if helloID.Client == "" {
+		helloID = utls.HelloChrome_Auto
+	}
+
+	raw, err := DefaultDNSCache.DialContext(context.Background(), network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &utls.Config{}
+	if d.config != nil {
+		config = d.config.Clone()
+	}
+	config.ServerName = host
+	conn := utls.UClient(raw, config, helloID)
+
+	// browser fingerprints offer h2, but payloads are written as HTTP/1.1
+	if err := conn.BuildHandshakeState(); err != nil {
+		raw.Close()
+		return nil, err
+	}
+	for _, ext := range conn.Extensions {
+		if alpn, ok := ext.(*utls.ALPNExtension); ok {
+			alpn.AlpnProtocols = []string{"http/1.1"}
+		}
+	}
+	if err := conn.BuildHandshakeState(); err != nil {
+		raw.Close()
+		return nil, err
+	}
+
+	if err := conn.Handshake(); err != nil {
+		raw.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,41 @@
+//go:build utls
+// +build utls
+
+package mcgo
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	utls "github.com/refraction-networking/utls"
+)
+
+func TestUTLSDialer(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	// the server prefers h2, so only the dialer's ALPN keeps the connection on HTTP/1.1
+	srv.TLS = &tls.Config{NextProtos: []string{"h2", "http/1.1"}}
+	srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	d := UTLSDialer{config: &utls.Config{RootCAs: roots}}
+	conn, err := d.Dial("tcp", strings.TrimPrefix(srv.URL, "https://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if proto := conn.(*utls.UConn).ConnectionState().NegotiatedProtocol; proto != "http/1.1" {
+		t.Fatalf("protocol: %q | expected http/1.1 to be negotiated", proto)
+	}
+
+	if _, err := (UTLSDialer{}).Dial("tcp", strings.TrimPrefix(srv.URL, "https://")); err == nil {
+		t.Fatal("expected an untrusted certificate to fail the handshake")
+	}
+}
@@ -0,0 +1,92 @@
+package mcgo
+
+import (
+	"fmt"
+	"sort"
+)
+
+type PrivilegeChange struct {
+	Name string
+	Old  bool
+	New  bool
+}
+
+// Differences between two states of a profile. Skins are compared by texture hash, capes by id.
+// Privileges are only compared when both profiles have them.
+type ProfileDiff struct {
+	OldName, NewName string
+	NameChanged      bool
+
+	OldSkin, NewSkin Skin // active skins, zero if none
+	SkinChanged      bool
+
+	OldCape, NewCape Cape // active capes, zero if none
+	CapeChanged      bool
+	CapesAdded       []Cape
+	CapesRemoved     []Cape
+
+	Privileges []PrivilegeChange // sorted by name
+}
+
+// returns true if nothing changed
+func (d ProfileDiff) Empty() bool {
+	return !d.NameChanged && !d.SkinChanged && !d.CapeChanged && len(d.CapesAdded) == 0 && len(d.CapesRemoved) == 0 && len(d.Privileges) == 0
+}
+
+func DiffProfiles(old, new Profile) ProfileDiff {
+	diff := ProfileDiff{OldName: old.Name, NewName: new.Name}
+	diff.NameChanged = old.Name != new.Name
+
+	diff.OldSkin, _ = old.ActiveSkin()
+	diff.NewSkin, _ = new.ActiveSkin()
+	diff.SkinChanged = diff.OldSkin.Hash() != diff.NewSkin.Hash() || diff.OldSkin.Variant != diff.NewSkin.Variant
+
+	diff.OldCape, _ = old.ActiveCape()
+	diff.NewCape, _ = new.ActiveCape()
+	diff.CapeChanged = diff.OldCape.ID != diff.NewCape.ID
+
+	oldCapes := map[string]bool{}
+	for _, cape := range old.Capes {
+		oldCapes[cape.ID] = true
+	}
+	newCapes := map[string]bool{}
+	for _, cape := range new.Capes {
+		newCapes[cape.ID] = true
+		if !oldCapes[cape.ID] {
+			diff.CapesAdded = append(diff.CapesAdded, cape)
+		}
+	}
+	for _, cape := range old.Capes {
+		if !newCapes[cape.ID] {
+			diff.CapesRemoved = append(diff.CapesRemoved, cape)
+		}
+	}
+
+	if old.Privileges != nil && new.Privileges != nil {
+		for name, enabled := range new.Privileges {
+			if was, ok := old.Privileges[name]; ok && was != enabled {
+				diff.Privileges = append(diff.Privileges, PrivilegeChange{Name: name, Old: was, New: enabled})
+			}
+		}
+		sort.Slice(diff.Privileges, func(i, j int) bool { return diff.Privileges[i].Name < diff.Privileges[j].Name })
+	}
+
+	return diff
+}
+
+func describeSkin(skin Skin) string {
+	if skin.URL == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%v (%v)", skin.Hash(), skin.Variant)
+}
+
+func describeCape(cape Cape) string {
+	if cape.ID == "" {
+		return "none"
+	}
+	if cape.Alias != "" {
+		return cape.Alias
+	}
+	return cape.ID
+}
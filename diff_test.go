@@ -0,0 +1,35 @@
+package mcgo
+
+import "testing"
+
+func TestDiffProfiles(t *testing.T) {
+	old := Profile{
+		ID:         "abc",
+		Name:       "Old",
+		Skins:      []Skin{{State: "ACTIVE", URL: "http://textures.minecraft.net/texture/aaa", Variant: "CLASSIC"}},
+		Capes:      []Cape{{ID: "migrator", State: "ACTIVE"}},
+		Privileges: map[string]bool{"onlineChat": true, "telemetry": true},
+	}
+	new := Profile{
+		ID:         "abc",
+		Name:       "New",
+		Skins:      []Skin{{State: "ACTIVE", URL: "http://textures.minecraft.net/texture/aaa", Variant: "SLIM"}},
+		Capes:      []Cape{{ID: "migrator"}, {ID: "vanilla", State: "ACTIVE"}},
+		Privileges: map[string]bool{"onlineChat": false, "telemetry": true},
+	}
+
+	diff := DiffProfiles(old, new)
+	if !diff.NameChanged || !diff.SkinChanged || !diff.CapeChanged || diff.NewCape.ID != "vanilla" {
+		t.Fatalf("diff: %+v | expected name, skin variant and cape changes", diff)
+	}
+	if len(diff.CapesAdded) != 1 || len(diff.CapesRemoved) != 0 {
+		t.Fatalf("added: %v | removed: %v | expected vanilla added", diff.CapesAdded, diff.CapesRemoved)
+	}
+	if len(diff.Privileges) != 1 || diff.Privileges[0] != (PrivilegeChange{Name: "onlineChat", Old: true, New: false}) {
+		t.Fatalf("privileges: %+v | expected chat disabled", diff.Privileges)
+	}
+
+	if diff := DiffProfiles(old, old); !diff.Empty() {
+		t.Fatalf("diff: %+v | expected no changes", diff)
+	}
+}
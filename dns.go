@@ -0,0 +1,111 @@
+package mcgo
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// Caches DNS lookups for the standard client and the default name change dialer, so repeated requests don't wait on (or jitter with) the resolver.
+// Cached results are kept for TTL regardless of the records' own TTL, pinned hosts never expire.
+type DNSCache struct {
+	TTL      time.Duration
+	Resolver *net.Resolver // net.DefaultResolver if nil
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+	pinned  map[string][]string
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+var DefaultDNSCache = NewDNSCache(time.Minute * 5)
+
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	return &DNSCache{
+		TTL:     ttl,
+		entries: map[string]dnsEntry{},
+		pinned:  map[string][]string{},
+	}
+}
+
+// always resolve host to addrs, e.g. to work around a bad resolver
+func (c *DNSCache) Pin(host string, addrs ...string) {
+	c.mu.Lock()
+	c.pinned[host] = addrs
+	c.mu.Unlock()
+}
+
+func (c *DNSCache) Unpin(host string) {
+	c.mu.Lock()
+	delete(c.pinned, host)
+	c.mu.Unlock()
+}
+
+// drops every cached (not pinned) lookup
+func (c *DNSCache) Flush() {
+	c.mu.Lock()
+	c.entries = map[string]dnsEntry{}
+	c.mu.Unlock()
+}
+
+// returns the addresses of host, from the cache if possible
+func (c *DNSCache) Lookup(ctx context.Context, host string) ([]string, error) {
+	if net.ParseIP(host) != nil {
+		return []string{host}, nil
+	}
+
+	c.mu.Lock()
+	if addrs, ok := c.pinned[host]; ok {
+		c.mu.Unlock()
+		return addrs, nil
+	}
+	if entry, ok := c.entries[host]; ok && time.Now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.addrs, nil
+	}
+	c.mu.Unlock()
+
+	resolver := c.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: time.Now().Add(c.TTL)}
+	c.mu.Unlock()
+
+	return addrs, nil
+}
+
+// dials addr using cached addresses, trying each until one connects
+func (c *DNSCache) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := c.Lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: time.Second * 30, KeepAlive: time.Second * 30}
+	for _, ip := range addrs {
+		var conn net.Conn
+		conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
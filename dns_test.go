@@ -0,0 +1,36 @@
+package mcgo
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDNSCache(t *testing.T) {
+	cache := NewDNSCache(time.Minute)
+
+	cache.Pin("api.minecraftservices.com", "127.0.0.1")
+	addrs, err := cache.Lookup(context.Background(), "api.minecraftservices.com")
+	if err != nil || len(addrs) != 1 || addrs[0] != "127.0.0.1" {
+		t.Fatalf("err: %v | addrs: %v | expected pinned address", err, addrs)
+	}
+
+	cache.entries["example.com"] = dnsEntry{addrs: []string{"10.0.0.1"}, expires: time.Now().Add(time.Minute)}
+	if addrs, _ := cache.Lookup(context.Background(), "example.com"); len(addrs) != 1 || addrs[0] != "10.0.0.1" {
+		t.Fatalf("addrs: %v | expected cached address", addrs)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	conn, err := cache.DialContext(context.Background(), "tcp", net.JoinHostPort("api.minecraftservices.com", port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+}
@@ -0,0 +1,105 @@
+package mcgo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A name to snipe. Higher Priority targets get accounts first when drops conflict.
+type DropTarget struct {
+	Username string
+	DropTime time.Time
+	Priority int
+	Accounts int    // accounts wanted for this drop
+	Strategy string // name of the Strategy the drop is sniped with, see Strategies.For
+}
+
+type DropAssignment struct {
+	Target   DropTarget
+	Accounts []*MCaccount
+	Short    int // accounts wanted but not assigned because of conflicts
+}
+
+// Allocation of accounts to drops, in the order of the targets given to ResolveDrops.
+type DropPlan struct {
+	Assignments []DropAssignment
+}
+
+// returns the assignments that didn't get every account they wanted
+func (p DropPlan) Starved() []DropAssignment {
+	var starved []DropAssignment
+	for _, a := range p.Assignments {
+		if a.Short > 0 {
+			starved = append(starved, a)
+		}
+	}
+	return starved
+}
+
+func (p DropPlan) String() string {
+	var b strings.Builder
+	for _, a := range p.Assignments {
+		fmt.Fprintf(&b, "%v @ %v (priority %v): %v/%v accounts", a.Target.Username, a.Target.DropTime.Format(time.RFC3339), a.Target.Priority, len(a.Accounts), a.Target.Accounts)
+		if a.Short > 0 {
+			fmt.Fprintf(&b, ", %v short", a.Short)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Allocates accounts to targets. Drops less than window apart conflict and can't share an account,
+// conflicting targets are served by priority (earlier drop first on ties) so the plan shows up front which target is short.
+func ResolveDrops(targets []DropTarget, accounts []*MCaccount, window time.Duration) DropPlan {
+	order := make([]int, len(targets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := targets[order[i]], targets[order[j]]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.DropTime.Before(b.DropTime)
+	})
+
+	plan := DropPlan{Assignments: make([]DropAssignment, len(targets))}
+	// drop times each account is already assigned to
+	busy := map[*MCaccount][]time.Time{}
+
+	conflicts := func(account *MCaccount, drop time.Time) bool {
+		for _, t := range busy[account] {
+			d := t.Sub(drop)
+			if d < 0 {
+				d = -d
+			}
+			if d < window {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, i := range order {
+		target := targets[i]
+		assignment := DropAssignment{Target: target}
+
+		for _, account := range accounts {
+			if len(assignment.Accounts) == target.Accounts {
+				break
+			}
+			if conflicts(account, target.DropTime) {
+				continue
+			}
+			assignment.Accounts = append(assignment.Accounts, account)
+			busy[account] = append(busy[account], target.DropTime)
+		}
+
+		assignment.Short = target.Accounts - len(assignment.Accounts)
+		plan.Assignments[i] = assignment
+	}
+
+	return plan
+}
@@ -0,0 +1,32 @@
+package mcgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveDrops(t *testing.T) {
+	drop := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	accounts := []*MCaccount{{Email: "a"}, {Email: "b"}, {Email: "c"}}
+
+	targets := []DropTarget{
+		{Username: "low", DropTime: drop, Priority: 1, Accounts: 2},
+		{Username: "high", DropTime: drop.Add(time.Second * 10), Priority: 5, Accounts: 2},
+		{Username: "later", DropTime: drop.Add(time.Hour), Priority: 0, Accounts: 3},
+	}
+
+	plan := ResolveDrops(targets, accounts, time.Minute)
+
+	if high := plan.Assignments[1]; len(high.Accounts) != 2 || high.Short != 0 {
+		t.Fatalf("high priority assignment: %+v | expected 2 accounts", high)
+	}
+	if low := plan.Assignments[0]; len(low.Accounts) != 1 || low.Short != 1 || low.Accounts[0].Email != "c" {
+		t.Fatalf("low priority assignment: %+v | expected the remaining account", low)
+	}
+	if later := plan.Assignments[2]; len(later.Accounts) != 3 {
+		t.Fatalf("later assignment: %+v | expected all accounts, it doesn't conflict", later)
+	}
+	if starved := plan.Starved(); len(starved) != 1 || starved[0].Target.Username != "low" {
+		t.Fatalf("starved: %+v", starved)
+	}
+}
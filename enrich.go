@@ -0,0 +1,209 @@
+package mcgo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// Public profile of the owner of a taken name. Legacy (never migrated to a Mojang or Microsoft account) and Demo (never bought the game)
+// are the only creation hints the APIs still give, legacy owners are old and often inactive.
+type OwnerProfile struct {
+	UUID        string
+	Name        string
+	SkinURL     string // empty when the owner uses a default skin
+	SkinVariant string // CLASSIC or SLIM
+	CapeURL     string
+	Legacy      bool
+	Demo        bool
+}
+
+// A scan result, with the owner's profile for taken names. EnrichErr is set if the owner could not be looked up.
+type EnrichedResult struct {
+	ScanResult
+	Owner     *OwnerProfile
+	EnrichErr error
+}
+
+type sessionProfileResp struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Properties []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"properties"`
+}
+
+type texturesPayload struct {
+	Textures struct {
+		Skin *struct {
+			URL      string `json:"url"`
+			Metadata struct {
+				Model string `json:"model"`
+			} `json:"metadata"`
+		} `json:"SKIN"`
+		Cape *struct {
+			URL string `json:"url"`
+		} `json:"CAPE"`
+	} `json:"textures"`
+}
+
+// fills in the skin and cape of owner from the session server
+func (account *MCaccount) sessionProfile(owner *OwnerProfile) error {
+	resp, err := account.client().Get("https://sessionserver.mojang.com/session/minecraft/profile/" + owner.UUID)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when requesting session profile of %v", resp.Status, owner.UUID),
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var profile sessionProfileResp
+	if err := json.Unmarshal(respBytes, &profile); err != nil {
+		return err
+	}
+
+	for _, property := range profile.Properties {
+		if property.Name != "textures" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(property.Value)
+		if err != nil {
+			return err
+		}
+		var textures texturesPayload
+		if err := json.Unmarshal(decoded, &textures); err != nil {
+			return err
+		}
+		if skin := textures.Textures.Skin; skin != nil {
+			owner.SkinURL = skin.URL
+			owner.SkinVariant = "CLASSIC"
+			if skin.Metadata.Model == "slim" {
+				owner.SkinVariant = "SLIM"
+			}
+		}
+		if cape := textures.Textures.Cape; cape != nil {
+			owner.CapeURL = cape.URL
+		}
+	}
+	return nil
+}
+
+// How long Enrich waits for more taken names before looking up a partial batch.
+var EnrichBatchWait = time.Second * 2
+
+// Second stage of a scan: passes every result of Scan through, looking up the owners of taken names first.
+// Owners are resolved to UUIDs in batches of 10 with the bulk lookup, then their skins are fetched from the session server,
+// both at the scanner's Rate. Taken names come out after the others that were scanned with them, the order is not kept.
+func (s *Scanner) Enrich(ctx context.Context, results <-chan ScanResult) <-chan EnrichedResult {
+	enriched := make(chan EnrichedResult, s.Buffer)
+
+	go func() {
+		defer close(enriched)
+
+		var last time.Time
+		var batch []ScanResult
+
+		send := func(r EnrichedResult) bool {
+			select {
+			case enriched <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		flush := func() bool {
+			if len(batch) == 0 {
+				return true
+			}
+			defer func() { batch = nil }()
+
+			names := make([]string, len(batch))
+			for i, r := range batch {
+				names[i] = r.Name
+			}
+
+			if !s.wait(ctx, &last) {
+				return false
+			}
+			profiles, err := bulkLookup(s.Account.client(), names)
+
+			owners := map[string]*OwnerProfile{}
+			for _, p := range profiles {
+				owners[strings.ToLower(p.Name)] = &OwnerProfile{UUID: p.ID, Name: p.Name, Legacy: p.Legacy, Demo: p.Demo}
+			}
+
+			for _, r := range batch {
+				result := EnrichedResult{ScanResult: r, EnrichErr: err}
+				if owner, ok := owners[strings.ToLower(r.Name)]; ok {
+					if !s.wait(ctx, &last) {
+						return false
+					}
+					result.Owner = owner
+					result.EnrichErr = s.Account.sessionProfile(owner)
+				} else if err == nil {
+					result.EnrichErr = fmt.Errorf("owner of %v not found, the name may have been released", r.Name)
+				}
+				if !send(result) {
+					return false
+				}
+			}
+			return true
+		}
+
+		timer := time.NewTimer(EnrichBatchWait)
+		defer timer.Stop()
+
+		for {
+			select {
+			case r, ok := <-results:
+				if !ok {
+					flush()
+					return
+				}
+				if r.Err != nil || r.Status != NameTaken {
+					if !send(EnrichedResult{ScanResult: r}) {
+						return
+					}
+					continue
+				}
+				batch = append(batch, r)
+				if len(batch) == 10 && !flush() {
+					return
+				}
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(EnrichBatchWait)
+			case <-timer.C:
+				if !flush() {
+					return
+				}
+				timer.Reset(EnrichBatchWait)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return enriched
+}
@@ -0,0 +1,159 @@
+package mcgo
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Mojang's yggdrasil session key as the launcher bundles it (yggdrasil_session_pubkey.der), base64 DER
+const yggdrasilSessionKey = "MIICIjANBgkqhkiG9w0BAQEFAAOCAg8AMIICCgKCAgEAylB4B6m5lz7jwrcFz6Fd/fnfUhcvlxsTSn5kIK/2aGG1C3kMy4VjhwlxF6BFUSnfxhNswPjh3ZitkBxEAFY25uzkJFRwHwVA9mdwjashXILtR6OqdLXXFVyUPIURLOSWqGNBtb08EN5fMnG8iFLgEJIBMxs9BvF3s3/FhuHyPKiVTZmXY0WY4ZyYqvoKR+XjaTRPPvBsDa4WI2u1zxXMeHlodT3lnCzVvyOYBLXL6CJgByuOxccJ8hnXfF9yY4F0aeL080Jz/3+EBNG8RO4ByhtBf4Ny8NQ6stWsjfeUIvH7bU/4zCYcYOq4WrInXHqS8qruDmIl7P5XXGcabuzQstPf/h2CRAUpP/PlHXcMlvewjmGU6MfDK+lifScNYwjPxRo4nKTGFZf/0aqHCh/EAsQyLKrOIYRE0lDG3bzBh8ogIMLAugsAfBb6M3mqCqKaTMAf/VAjh5FFJnjS+7bE+bZEV0qwax1CEoPPJL1fIQjOS8zj086gjpGRCtSy9+bTPTfTR/SJ+VUB5G2IeCItkNHpJX2ygojFZ9n5Fnj7R9ZnOM+L8nyIjPu3aePvtcrXlyLhH/hvOfIOjPxOlqW+O5QwSFP4OEcyLAUgDdUgyW36Z5mB285uKW/ighzZsOTevVUG2QwDItObIV6i8RCxFbN2oDHyPaO5j1tTaBNyVt8CAwEAAQ=="
+
+// Keys entitlement signatures are verified with, Mojang's yggdrasil session key by default.
+var EntitlementKeys = []*rsa.PublicKey{mustParsePublicKey(yggdrasilSessionKey)}
+
+// Also trust the profile property keys PublicKeys fetches. Off by default, the fetch goes over the same network the entitlements do,
+// so whoever could forge the entitlements could answer it with their own key.
+var TrustFetchedEntitlementKeys = false
+
+var ErrEntitlementSignature = errors.New("entitlements are not signed by a trusted key")
+
+// What an account owns according to its signed entitlements.
+type Entitlements struct {
+	Names []string // sorted, like game_minecraft and product_minecraft
+	KeyID string   // id of the key minecraftservices says it signed with
+}
+
+func (e Entitlements) Has(name string) bool {
+	for _, n := range e.Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Entitlements) OwnsMinecraft() bool {
+	return e.Has("game_minecraft") || e.Has("product_minecraft")
+}
+
+// how far the local clock may be behind the one that signed the entitlements
+const entitlementSkew = time.Minute * 5
+
+// Checks the RS256 signature of a JWT against keys and decodes its claims into v.
+// Returns ErrEntitlementSignature if no key signed it.
+func verifyJWT(token string, keys []*rsa.PublicKey, v interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrEntitlementSignature
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(headerBytes, &header) != nil || header.Alg != "RS256" {
+		return ErrEntitlementSignature
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrEntitlementSignature
+	}
+
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	verified := false
+	for _, key := range keys {
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return ErrEntitlementSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+func entitlementKeys() ([]*rsa.PublicKey, error) {
+	keys := EntitlementKeys
+	if TrustFetchedEntitlementKeys {
+		fetched, err := PublicKeys()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(append([]*rsa.PublicKey(nil), keys...), fetched.ProfilePropertyKeys...)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no public keys to verify entitlements with")
+	}
+	return keys, nil
+}
+
+// Fetches the account's entitlements and verifies their signatures, so ownership can be trusted even when the response passed through a proxy.
+// Every item has to be signed for its own name by the signer of the list, and the signature over the whole list has to name the same
+// items and be valid now. Mojang's signatures don't name the account, the validity window is what keeps an old response of another
+// account from being replayed.
+func (account *MCaccount) Entitlements() (Entitlements, error) {
+	keys, err := entitlementKeys()
+	if err != nil {
+		return Entitlements{}, err
+	}
+
+	var resp entitlementsResponse
+	if err := account.getJSON("https://api.minecraftservices.com/entitlements/mcstore", &resp); err != nil {
+		return Entitlements{}, err
+	}
+
+	var signed struct {
+		Entitlements []struct {
+			Name string `json:"name"`
+		} `json:"entitlements"`
+		SignerID string `json:"signerId"`
+		Nbf      int64  `json:"nbf"`
+		Exp      int64  `json:"exp"`
+	}
+	if err := verifyJWT(resp.Signature, keys, &signed); err != nil {
+		return Entitlements{}, err
+	}
+	now := account.now()
+	if signed.Exp == 0 || !now.Before(time.Unix(signed.Exp, 0)) || now.Before(time.Unix(signed.Nbf, 0).Add(-entitlementSkew)) {
+		return Entitlements{}, ErrEntitlementSignature
+	}
+	signedNames := map[string]bool{}
+	for _, e := range signed.Entitlements {
+		signedNames[e.Name] = true
+	}
+
+	entitlements := Entitlements{KeyID: resp.KeyID, Names: []string{}}
+	for _, item := range resp.Items {
+		var claims struct {
+			Name     string `json:"name"`
+			SignerID string `json:"signerId"`
+		}
+		if err := verifyJWT(item.Signature, keys, &claims); err != nil {
+			return Entitlements{}, err
+		}
+		if claims.Name != item.Name || claims.SignerID != signed.SignerID || !signedNames[item.Name] {
+			return Entitlements{}, ErrEntitlementSignature
+		}
+		entitlements.Names = append(entitlements.Names, item.Name)
+	}
+	if len(entitlements.Names) != len(signedNames) {
+		return Entitlements{}, ErrEntitlementSignature
+	}
+
+	sort.Strings(entitlements.Names)
+	return entitlements, nil
+}
@@ -0,0 +1,98 @@
+package mcgo
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func signTestJWT(t *testing.T, key *rsa.PrivateKey, claims interface{}) string {
+	body, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." + base64.RawURLEncoding.EncodeToString(body)
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestEntitlements(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	defer func(keys []*rsa.PublicKey) { EntitlementKeys = keys }(EntitlementKeys)
+	EntitlementKeys = []*rsa.PublicKey{srv.SigningKey()}
+
+	owner := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	entitlements, err := owner.Entitlements()
+	if err != nil || !entitlements.OwnsMinecraft() || !reflect.DeepEqual(entitlements.Names, []string{"game_minecraft", "product_minecraft"}) {
+		t.Fatalf("err: %v | entitlements: %+v | expected both minecraft entitlements", err, entitlements)
+	}
+
+	unowned := newFakeAccount(srv, mcgotest.Account{})
+	if entitlements, err := unowned.Entitlements(); err != nil || entitlements.OwnsMinecraft() {
+		t.Fatalf("err: %v | entitlements: %+v | expected a verified empty list", err, entitlements)
+	}
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	EntitlementKeys = []*rsa.PublicKey{&other.PublicKey}
+	if _, err := owner.Entitlements(); err != ErrEntitlementSignature {
+		t.Fatalf("err: %v | expected entitlements signed by another key to be rejected", err)
+	}
+
+	// a response signed two hours ago has expired
+	EntitlementKeys = []*rsa.PublicKey{srv.SigningKey()}
+	srv.SetClock(mcgotest.NewFakeClock(time.Now().Add(-time.Hour * 2)))
+	if _, err := owner.Entitlements(); err != ErrEntitlementSignature {
+		t.Fatalf("err: %v | expected an expired response to be rejected", err)
+	}
+}
+
+func TestEntitlementKeysDefault(t *testing.T) {
+	if len(EntitlementKeys) != 1 || EntitlementKeys[0].N.BitLen() != 4096 || TrustFetchedEntitlementKeys {
+		t.Fatal("expected only the bundled yggdrasil session key to be trusted by default")
+	}
+}
+
+func TestVerifyJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []*rsa.PublicKey{&key.PublicKey}
+
+	token := signTestJWT(t, key, map[string]string{"name": "game_minecraft"})
+	var claims struct {
+		Name string `json:"name"`
+	}
+	if err := verifyJWT(token, keys, &claims); err != nil || claims.Name != "game_minecraft" {
+		t.Fatalf("err: %v | claims: %+v | expected the signed name", err, claims)
+	}
+
+	// the payload of another token with the signature of the first
+	original := strings.Split(token, ".")
+	forged := strings.Split(signTestJWT(t, key, map[string]string{"name": "product_minecraft"}), ".")
+	swapped := strings.Join([]string{original[0], forged[1], original[2]}, ".")
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + forged[1] + "."
+	for _, bad := range []string{"", "a.b", swapped, unsigned} {
+		if err := verifyJWT(bad, keys, &claims); err != ErrEntitlementSignature {
+			t.Fatalf("err: %v | token: %q | expected the token to be rejected", err, bad)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package mcgo
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFromEnv(t *testing.T) {
+	vars := map[string]string{EnvEmail: "env@example.com", EnvPassword: "pw", EnvType: "MS", EnvProxy: "http://127.0.0.1:8080"}
+	for k, v := range vars {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	acc, err := FromEnv()
+	if err != nil || acc.Email != "env@example.com" || acc.Type != Ms || acc.Client == nil || acc.Dialer == nil {
+		t.Fatalf("err: %v | account: %+v | expected an ms account with a proxy client and dialer", err, acc)
+	}
+
+	os.Unsetenv(EnvPassword)
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected an error without a password or bearer")
+	}
+
+	os.Setenv(EnvBearer, "bearer")
+	os.Setenv(EnvType, "xbox")
+	defer os.Unsetenv(EnvBearer)
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected an error for an unknown type")
+	}
+}
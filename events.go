@@ -0,0 +1,45 @@
+package mcgo
+
+import "time"
+
+type EventType string
+
+const (
+	EventUsernameChanged  EventType = "username_changed"
+	EventSkinChanged      EventType = "skin_changed"
+	EventTokenInvalidated EventType = "token_invalidated"
+	EventTokenExpired     EventType = "token_expired"
+	EventCapeChanged      EventType = "cape_changed"
+	EventPrivilegeChanged EventType = "privilege_changed"
+
+	EventTokenRefreshed     EventType = "token_refreshed"
+	EventTokenRefreshFailed EventType = "token_refresh_failed"
+)
+
+type Priority int
+
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+)
+
+// something that happened to an account, Account is the email of the account it concerns
+type Event struct {
+	Type     EventType
+	Priority Priority
+	Account  string
+	Message  string
+	Time     time.Time
+}
+
+type EventHandler func(Event)
+
+func (account *MCaccount) newEvent(eventType EventType, priority Priority, message string) Event {
+	return Event{
+		Type:     eventType,
+		Priority: priority,
+		Account:  account.Email,
+		Message:  message,
+		Time:     account.now(),
+	}
+}
@@ -0,0 +1,94 @@
+package mcgo_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kqzz/mcgo"
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+// The examples run against the fake server from mcgotest. Against the real APIs, leave Client and Dialer unset.
+
+func ExampleMCaccount_Authenticate() {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	srv.AddAccount(mcgotest.Account{Email: "steve@example.com", Password: "hunter2", Name: "Steve", OwnsGame: true})
+
+	account := &mcgo.MCaccount{
+		Email:    "steve@example.com",
+		Password: "hunter2",
+		Type:     mcgo.Mj,
+		Client:   &mcgo.Client{HTTP: srv.HTTPClient()},
+	}
+	if err := account.Authenticate(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(account.Username, account.Authenticated)
+	// Output: Steve true
+}
+
+func ExampleMCaccount_ChangeName() {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	fake := srv.AddAccount(mcgotest.Account{Name: "Steve", OwnsGame: true, NameChangeAllowed: true})
+
+	account := &mcgo.MCaccount{
+		Bearer:   fake.Bearer,
+		Username: fake.Name,
+		Client:   &mcgo.Client{HTTP: srv.HTTPClient()},
+		Dialer:   srv,
+	}
+
+	// the name change is sent at the given time, here right away
+	ret, err := account.ChangeName("Alex", time.Now(), false)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(ret.ChangedName, ret.StatusCode, account.Username)
+	// Output: true 200 Alex
+}
+
+func ExampleMCaccount_Burst() {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	fake := srv.AddAccount(mcgotest.Account{OwnsGame: true})
+
+	account := &mcgo.MCaccount{
+		Bearer: fake.Bearer,
+		Client: &mcgo.Client{HTTP: srv.HTTPClient()},
+		Dialer: srv,
+	}
+
+	// three profile creates spread over 20ms around the drop, only one of them can get the name
+	dropTime := time.Now().Add(time.Millisecond * 50)
+	result := account.Burst("Sniped", dropTime, true, mcgo.BurstOptions{Requests: 3, Spread: time.Millisecond * 20})
+	fmt.Println(result.Succeeded(), account.Username)
+	// Output: true Sniped
+}
+
+func ExampleCheckAll() {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	var accounts []*mcgo.MCaccount
+	for _, fake := range []mcgotest.Account{{Name: "Owner", OwnsGame: true}, {OwnsGame: true}, {}} {
+		stored := srv.AddAccount(fake)
+		accounts = append(accounts, &mcgo.MCaccount{Bearer: stored.Bearer, Client: &mcgo.Client{HTTP: srv.HTTPClient()}})
+	}
+
+	caps, err := mcgo.CheckAll(accounts, mcgo.SoftFail)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, c := range caps {
+		fmt.Printf("owns game: %v, has profile: %v, can create profile: %v\n", c.OwnsGame, c.HasProfile, c.CanCreateProfile)
+	}
+	// Output:
+	// owns game: true, has profile: true, can create profile: false
+	// owns game: true, has profile: false, can create profile: true
+	// owns game: false, has profile: false, can create profile: false
+}
@@ -0,0 +1,140 @@
+package mcgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A burst as stored in an experiment log. Variant identifies the strategy and parameters, so records with the same variant are comparable.
+type ExperimentRecord struct {
+	Time     time.Time         `json:"time"`
+	Username string            `json:"username"`
+	Variant  string            `json:"variant"`
+	Options  BurstOptions      `json:"options"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Won      bool              `json:"won"`
+}
+
+type VariantStats struct {
+	Attempts int
+	Wins     int
+	WinRate  float64
+}
+
+// Append only log of bursts (one json record per line) used to compare staggering strategies across drops.
+type ExperimentLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+func OpenExperimentLog(path string) *ExperimentLog {
+	return &ExperimentLog{path: path}
+}
+
+// name of the variant a burst ran with, e.g. "gaussian requests=3 spread=50ms offset=-10ms proxy=eu"
+func experimentVariant(opts BurstOptions, tags map[string]string) string {
+	parts := []string{
+		string(opts.Strategy),
+		fmt.Sprintf("requests=%v", opts.Requests),
+		fmt.Sprintf("spread=%v", opts.Spread),
+		fmt.Sprintf("offset=%v", opts.Offset),
+	}
+
+	var keys []string
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+"="+tags[k])
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Appends the burst to the log, tags are extra parameters of the experiment (proxy region, account type, ...) that become part of its variant.
+func (l *ExperimentLog) Record(result BurstResult, tags map[string]string) error {
+	record := ExperimentRecord{
+		Time:     result.DropTime.UTC(),
+		Username: result.Username,
+		Variant:  experimentVariant(result.Options, tags),
+		Options:  result.Options,
+		Tags:     tags,
+		Won:      result.Succeeded(),
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// returns every record in the log, oldest first. A missing log has no records.
+func (l *ExperimentLog) Records() ([]ExperimentRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []ExperimentRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record ExperimentRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// win rate of each variant over the bursts recorded since the given time
+func (l *ExperimentLog) WinRates(since time.Time) (map[string]VariantStats, error) {
+	records, err := l.Records()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := map[string]VariantStats{}
+	for _, record := range records {
+		if record.Time.Before(since) {
+			continue
+		}
+		s := stats[record.Variant]
+		s.Attempts++
+		if record.Won {
+			s.Wins++
+		}
+		s.WinRate = float64(s.Wins) / float64(s.Attempts)
+		stats[record.Variant] = s
+	}
+	return stats, nil
+}
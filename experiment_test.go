@@ -0,0 +1,39 @@
+package mcgo
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExperimentLog(t *testing.T) {
+	log := OpenExperimentLog(filepath.Join(t.TempDir(), "experiments.jsonl"))
+	drop := time.Now()
+
+	uniform := BurstOptions{Requests: 3, Spread: time.Millisecond * 50, Strategy: StaggerUniform}
+	gaussian := BurstOptions{Requests: 3, Spread: time.Millisecond * 50, Strategy: StaggerGaussian}
+	won := []NameChangeReturn{{ChangedName: true}}
+
+	bursts := []BurstResult{
+		{DropTime: drop, Options: uniform, Results: won},
+		{DropTime: drop, Options: uniform},
+		{DropTime: drop, Options: gaussian, Results: won},
+		{DropTime: drop.Add(-time.Hour), Options: gaussian},
+	}
+	for _, b := range bursts {
+		if err := log.Record(b, map[string]string{"proxy": "eu"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rates, err := log.WinRates(drop.Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := rates["uniform requests=3 spread=50ms offset=0s proxy=eu"]
+	g := rates["gaussian requests=3 spread=50ms offset=0s proxy=eu"]
+	if u.Attempts != 2 || u.WinRate != 0.5 || g.Attempts != 1 || g.WinRate != 1 {
+		t.Fatalf("rates: %+v", rates)
+	}
+}
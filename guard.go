@@ -0,0 +1,78 @@
+package mcgo
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrChangeInFlight = errors.New("a name change for this account and name is in flight or ended ambiguously")
+
+// Stores in-flight markers for name changes. Implement it on top of a shared store (redis, a database) to guard accounts used by several processes.
+type ChangeGuard interface {
+	// marks key as in flight, returns false if it already was
+	Acquire(key string) (bool, error)
+	Release(key string) error
+}
+
+// ChangeGuard for a single process.
+type MemoryGuard struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+}
+
+func NewMemoryGuard() *MemoryGuard {
+	return &MemoryGuard{keys: map[string]time.Time{}}
+}
+
+func (g *MemoryGuard) Acquire(key string) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.keys[key]; ok {
+		return false, nil
+	}
+	g.keys[key] = time.Now()
+	return true, nil
+}
+
+func (g *MemoryGuard) Release(key string) error {
+	g.mu.Lock()
+	delete(g.keys, key)
+	g.mu.Unlock()
+	return nil
+}
+
+// key a change of account to username is guarded under
+func ChangeKey(account *MCaccount, username string) string {
+	id := account.UUID
+	if id == "" {
+		id = strings.ToLower(account.Email)
+	}
+	return id + ":" + strings.ToLower(username)
+}
+
+// Like ChangeName, but refuses with ErrChangeInFlight while an earlier change of this account to username hasn't been settled.
+// A change is settled once a status code was read, or if it failed before the payload was fully sent. When the payload was sent
+// but no status could be read, the marker is kept: check whether the name changed, then release ChangeKey(account, username) before retrying.
+func (account *MCaccount) GuardedChangeName(guard ChangeGuard, username string, changeTime time.Time, createProfile bool) (NameChangeReturn, error) {
+	key := ChangeKey(account, username)
+
+	acquired, err := guard.Acquire(key)
+	if err != nil {
+		return NameChangeReturn{Username: username}, err
+	}
+	if !acquired {
+		return NameChangeReturn{Username: username}, ErrChangeInFlight
+	}
+
+	ret, err := account.ChangeName(username, changeTime, createProfile)
+	if ret.StatusCode == 0 && !ret.SendTime.IsZero() {
+		return ret, err
+	}
+
+	if releaseErr := guard.Release(key); releaseErr != nil && err == nil {
+		err = releaseErr
+	}
+	return ret, err
+}
@@ -0,0 +1,30 @@
+package mcgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestGuardedChangeName(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	guard := NewMemoryGuard()
+	srv.BlockName("Taken")
+
+	// a change that got a status is settled, so retrying is allowed
+	if _, err := acc.GuardedChangeName(guard, "Taken", time.Now(), true); err != nil {
+		t.Fatal(err)
+	}
+	if ret, err := acc.GuardedChangeName(guard, "Guarded", time.Now(), true); err != nil || !ret.ChangedName {
+		t.Fatalf("err: %v | ret: %+v | expected successful create", err, ret)
+	}
+
+	guard.Acquire(ChangeKey(acc, "Other"))
+	if _, err := acc.GuardedChangeName(guard, "other", time.Now(), true); err != ErrChangeInFlight {
+		t.Fatalf("err: %v | expected ErrChangeInFlight", err)
+	}
+}
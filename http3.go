@@ -0,0 +1,52 @@
+//go:build quic
+// +build quic
+
+package mcgo
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	quic "github.com/quic-go/quic-go"
+)
+
+// Times one QUIC handshake offering h3 with addr, resolved through DefaultDNSCache like the TCP dialers.
+// config's ServerName and NextProtos are filled in, the certificate is verified if it's nil.
+func probeHTTP3(ctx context.Context, addr string, config *tls.Config) (time.Duration, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return 0, err
+	}
+	addrs, err := DefaultDNSCache.Lookup(ctx, host)
+	if err != nil {
+		return 0, err
+	}
+	udpAddr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(addrs[0], port))
+	if err != nil {
+		return 0, err
+	}
+
+	packetConn, err := net.ListenUDP("udp", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer packetConn.Close()
+
+	if config == nil {
+		config = &tls.Config{}
+	}
+	config = config.Clone()
+	config.ServerName = host
+	config.NextProtos = []string{"h3"}
+
+	start := time.Now()
+	conn, err := quic.Dial(ctx, packetConn, udpAddr, config, nil)
+	if err != nil {
+		return 0, err
+	}
+	handshake := time.Since(start)
+	conn.CloseWithError(0, "")
+	return handshake, nil
+}
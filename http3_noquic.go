@@ -0,0 +1,15 @@
+//go:build !quic
+// +build !quic
+
+package mcgo
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+)
+
+// HTTP/3 probes need quic-go, which only builds with the quic tag.
+func probeHTTP3(ctx context.Context, addr string, config *tls.Config) (time.Duration, error) {
+	return 0, ErrHTTP3Unavailable
+}
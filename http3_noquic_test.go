@@ -0,0 +1,25 @@
+//go:build !quic
+// +build !quic
+
+package mcgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestBurstHTTP3ProbeUnavailable(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	result := acc.Burst("Probed", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 1, HTTP3Probe: "127.0.0.1:443"})
+	if h3 := result.Calibration.HTTP3; h3 == nil || h3.Error != ErrHTTP3Unavailable.Error() || h3.Samples != 0 {
+		t.Fatalf("http3: %+v | expected the probe to report the missing quic tag", h3)
+	}
+	if result.Calibration.Samples != calibrationProbes || !result.Succeeded() {
+		t.Fatalf("calibration: %+v | errors: %v | expected the TCP calibration and the burst to go ahead", result.Calibration, result.Errors)
+	}
+}
@@ -0,0 +1,62 @@
+//go:build quic
+// +build quic
+
+package mcgo
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	quic "github.com/quic-go/quic-go"
+)
+
+func TestProbeHTTP3(t *testing.T) {
+	// only for its certificate and a client trusting it
+	certs := httptest.NewTLSServer(http.NotFoundHandler())
+	defer certs.Close()
+
+	ln, err := quic.ListenAddr("127.0.0.1:0", &tls.Config{Certificates: certs.TLS.Certificates, NextProtos: []string{"h3"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept(context.Background())
+			if err != nil {
+				return
+			}
+			go func() {
+				<-conn.Context().Done()
+			}()
+		}
+	}()
+
+	config := &tls.Config{RootCAs: certs.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	handshake, err := probeHTTP3(ctx, ln.Addr().String(), config)
+	if err != nil || handshake <= 0 {
+		t.Fatalf("err: %v | handshake: %v | expected a timed QUIC handshake", err, handshake)
+	}
+
+	// the default config verifies the certificate
+	if _, err := probeHTTP3(ctx, ln.Addr().String(), nil); err == nil {
+		t.Fatal("expected an untrusted certificate to fail the probe")
+	}
+
+	closed, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := closed.LocalAddr().String()
+	closed.Close()
+	if c := calibrateHTTP3(addr, 3); c.Error == "" || c.Samples != 0 {
+		t.Fatalf("calibration: %+v | expected an endpoint without QUIC to be reported", c)
+	}
+}
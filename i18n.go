@@ -0,0 +1,99 @@
+package mcgo
+
+import (
+	"errors"
+	"sync"
+)
+
+// Translations of mcgo's error messages, keyed by the English message.
+type Catalog map[string]string
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Catalog{}
+)
+
+// the fixed English messages mcgo's errors can have, for building translations
+var englishMessages = []string{
+	"2fa code was rejected",
+	"2fa is enabled, set TOTPSecret or TOTPCode in MsAuthOptions",
+	"a name change for this account and name is in flight or ended ambiguously",
+	"account does not own minecraft",
+	"account has no microsoft refresh token, log in with MsDeviceCodeAuth first",
+	"account has no xbox live token, log in with a microsoft flow first",
+	"account is not authenticated",
+	"at least one security question answer was incorrect",
+	"bearer can't be refreshed, authenticate again",
+	"bearer is not a JWT",
+	"disconnected before receiving a namemc claim url",
+	"email is empty",
+	"email verification code was rejected",
+	"entitlements are not signed by a trusted key",
+	"failed microsoft authentication, invalid credentials",
+	"failed to grab name change info",
+	"http/3 probes need the quic build tag",
+	"interactive login needs the client id of your own Azure app in MsAuthOptions",
+	"invalid Rpsticket field probably",
+	"invalid credentials",
+	"invalid email or password",
+	"login page has no PPFT value or post url, the microsoft login flow probably changed",
+	"microsoft account belongs to someone under 18! add to family for this to work",
+	"microsoft asked to verify the account's email, set a ChallengeSolver in MsAuthOptions",
+	"mojang API ratelimit reached",
+	"namemc claims are not available, mcgo was built with the nonamemc tag",
+	"no credential is stored under this key",
+	"no public keys to verify entitlements with",
+	"not enough security question answers provided",
+	"practice creates a real profile, pass PracticeConfirmation to confirm",
+	"practice needs an account that owns minecraft and has no profile yet",
+	"pre-built connection was already used",
+	"profile change did not propagate before the timeout",
+	"public key is not an RSA key",
+	"reached end of authenticate function! Shouldn't be possible. most likely 'failed to auth' status code changed",
+	"receipt signature does not match its contents",
+	"receipts can only be made for successful name changes",
+	"refresh needs a bearer and the client token it was issued to",
+	"security questions not properly loaded",
+	"server closed the connection after the latency probe",
+	"set MCGO_BEARER, or MCGO_EMAIL and MCGO_PASSWORD",
+	"the account has neither an email nor a uuid to store credentials under",
+	"the account used up its request budget for today",
+	"the client is read-only, the account was not changed",
+	"the device code expired before the login was completed",
+	"the mojang account was migrated to microsoft, log in with a microsoft flow",
+	"tls sessions can't be saved before go 1.21",
+	"token cache can't be decrypted, wrong passphrase or corrupted file",
+	"xbox profile response has no user",
+	"you have no xbox account! Sign up for one to continue",
+}
+
+// returns the English messages that can be translated, the keys a Catalog should have
+func Messages() []string {
+	return append([]string(nil), englishMessages...)
+}
+
+// Registers translations for lang (e.g. "de", "pt-BR"), replacing any registered before.
+func RegisterCatalog(lang string, catalog Catalog) {
+	catalogsMu.Lock()
+	catalogs[lang] = catalog
+	catalogsMu.Unlock()
+}
+
+// Returns the message of err in lang. Wrapped errors are unwrapped until one has a translation,
+// if none does the English message of err is returned.
+func Localize(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+
+	catalogsMu.RLock()
+	catalog := catalogs[lang]
+	catalogsMu.RUnlock()
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if translated, ok := catalog[e.Error()]; ok {
+			return translated
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,64 @@
+package mcgo
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	RegisterCatalog("de", Catalog{"invalid email or password": "ungültige E-Mail oder Passwort"})
+
+	err := &RequestError{StatusCode: 403, Err: errors.New("invalid email or password")}
+	if msg := Localize(err, "de"); msg != "ungültige E-Mail oder Passwort" {
+		t.Fatalf("message: %v | expected german translation", msg)
+	}
+	if msg := Localize(err, "fr"); msg != "invalid email or password" {
+		t.Fatalf("message: %v | expected english fallback", msg)
+	}
+}
+
+// every fixed error message in the package must be listed, so translators see it
+func TestMessagesComplete(t *testing.T) {
+	known := map[string]bool{}
+	for _, m := range Messages() {
+		known[m] = true
+	}
+
+	files, _ := filepath.Glob("*.go")
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "New" {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "errors" {
+				return true
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+				msg, _ := strconv.Unquote(lit.Value)
+				if !known[msg] {
+					t.Errorf("%v: message %q missing from englishMessages", fset.Position(lit.Pos()), msg)
+				}
+			}
+			return true
+		})
+	}
+}
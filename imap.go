@@ -0,0 +1,181 @@
+package mcgo
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A ChallengeSolver that reads the code from the account's inbox over IMAP: unread mails from From are searched for
+// CodePattern every Poll until one has a code or the context is done. Mails are marked read as they're fetched.
+type IMAPSolver struct {
+	Addr        string // host:port of the IMAP server, e.g. imap.gmail.com:993
+	Username    string
+	Password    string
+	From        string                                       // sender of the verification mails, account-security-noreply@accountprotection.microsoft.com if empty
+	CodePattern *regexp.Regexp                               // the first group is the code, a 4 to 8 digit number after "code" if nil
+	Poll        time.Duration                                // 10 seconds if 0
+	Dial        func(network, addr string) (net.Conn, error) // TLS if nil
+}
+
+var defaultCodePattern = regexp.MustCompile(`(?i)code\W{0,40}?(\d{4,8})\b`)
+
+func (s *IMAPSolver) Solve(ctx context.Context, challenge Challenge) (string, error) {
+	poll := s.Poll
+	if poll <= 0 {
+		poll = time.Second * 10
+	}
+
+	for {
+		code, err := s.check(ctx, challenge.SentAt)
+		if deadline, ok := ctx.Deadline(); err != nil && ok && !time.Now().Before(deadline) {
+			// the connection's deadline can go off just before ctx's
+			<-ctx.Done()
+		}
+		if err != nil && ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if err != nil || code != "" {
+			return code, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(poll):
+		}
+	}
+}
+
+// looks through the unread verification mails sent since the day of since once, returning "" if none has a code.
+// The connection is closed when ctx is done, so a server that stops answering can't outlast it.
+func (s *IMAPSolver) check(ctx context.Context, since time.Time) (string, error) {
+	dial := s.Dial
+	if dial == nil {
+		dial = func(network, addr string) (net.Conn, error) {
+			return (&tls.Dialer{}).DialContext(ctx, network, addr)
+		}
+	}
+	from := s.From
+	if from == "" {
+		from = "account-security-noreply@accountprotection.microsoft.com"
+	}
+	pattern := s.CodePattern
+	if pattern == nil {
+		pattern = defaultCodePattern
+	}
+
+	conn, err := dial("tcp", s.Addr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	c := &imapConn{conn: conn, r: bufio.NewReader(conn)}
+	if _, err := c.r.ReadString('\n'); err != nil {
+		return "", err
+	}
+	if _, _, err := c.cmd("LOGIN %v %v", imapQuote(s.Username), imapQuote(s.Password)); err != nil {
+		return "", err
+	}
+	defer c.cmd("LOGOUT")
+	if _, _, err := c.cmd("SELECT INBOX"); err != nil {
+		return "", err
+	}
+
+	search := "SEARCH UNSEEN FROM " + imapQuote(from)
+	if !since.IsZero() {
+		search += " SINCE " + since.UTC().Format("2-Jan-2006")
+	}
+	lines, _, err := c.cmd("%v", search)
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "* SEARCH") {
+			ids = append(ids, strings.Fields(strings.TrimPrefix(line, "* SEARCH"))...)
+		}
+	}
+
+	// newest first, an older mail may hold a code that was already used
+	for i := len(ids) - 1; i >= 0; i-- {
+		_, body, err := c.cmd("FETCH %v BODY[TEXT]", ids[i])
+		if err != nil {
+			return "", err
+		}
+		if match := pattern.FindStringSubmatch(body); match != nil {
+			return match[1], nil
+		}
+	}
+	return "", nil
+}
+
+type imapConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+}
+
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// sends a command and reads its response: the untagged lines, and the literals they carried (e.g. a fetched body)
+func (c *imapConn) cmd(format string, args ...interface{}) ([]string, string, error) {
+	c.tag++
+	tag := fmt.Sprintf("a%d", c.tag)
+	if _, err := fmt.Fprintf(c.conn, "%v %v\r\n", tag, fmt.Sprintf(format, args...)); err != nil {
+		return nil, "", err
+	}
+
+	var lines []string
+	var literals strings.Builder
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return nil, "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		// a literal of n bytes follows lines ending in {n}
+		if i := strings.LastIndex(line, "{"); i >= 0 && strings.HasSuffix(line, "}") {
+			if n, err := strconv.Atoi(line[i+1 : len(line)-1]); err == nil {
+				literal := make([]byte, n)
+				if _, err := io.ReadFull(c.r, literal); err != nil {
+					return nil, "", err
+				}
+				literals.Write(literal)
+			}
+		}
+
+		if strings.HasPrefix(line, tag+" ") {
+			status := strings.TrimPrefix(line, tag+" ")
+			if !strings.HasPrefix(status, "OK") {
+				return lines, literals.String(), fmt.Errorf("imap command failed: %v", status)
+			}
+			return lines, literals.String(), nil
+		}
+		lines = append(lines, line)
+	}
+}
@@ -0,0 +1,99 @@
+package mcgo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serves one IMAP session with an inbox holding the given mail bodies, all from the verification sender
+func fakeIMAP(t *testing.T, bodies []string) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		fmt.Fprint(conn, "* OK ready\r\n")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			parts := strings.Fields(line)
+			tag, cmd := parts[0], parts[1]
+			switch cmd {
+			case "LOGIN":
+				if parts[2] != `"me@example.com"` || parts[3] != `"app-password"` {
+					fmt.Fprintf(conn, "%v NO bad login\r\n", tag)
+					continue
+				}
+			case "SEARCH":
+				ids := []string{}
+				for i := range bodies {
+					ids = append(ids, fmt.Sprint(i+1))
+				}
+				fmt.Fprintf(conn, "* SEARCH %v\r\n", strings.Join(ids, " "))
+			case "FETCH":
+				var i int
+				fmt.Sscan(parts[2], &i)
+				fmt.Fprintf(conn, "* %v FETCH (BODY[TEXT] {%v}\r\n%v)\r\n", i, len(bodies[i-1]), bodies[i-1])
+			case "LOGOUT":
+				fmt.Fprintf(conn, "%v OK bye\r\n", tag)
+				return
+			}
+			fmt.Fprintf(conn, "%v OK done\r\n", tag)
+		}
+	}()
+	return ln
+}
+
+func TestIMAPSolver(t *testing.T) {
+	ln := fakeIMAP(t, []string{"Security code: 1111", "Use this security code\r\nfor your account:\r\n\r\nSecurity code: 4820193\r\n"})
+	defer ln.Close()
+
+	solver := &IMAPSolver{Addr: ln.Addr().String(), Username: "me@example.com", Password: "app-password", Dial: net.Dial}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	code, err := solver.Solve(ctx, Challenge{Kind: ChallengeEmail, Email: "me@example.com", SentAt: time.Now()})
+	if err != nil || code != "4820193" {
+		t.Fatalf("err: %v | code: %v | expected the code of the newest mail", err, code)
+	}
+}
+
+func TestIMAPSolverHungServer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// greets, then never answers the login
+		fmt.Fprint(conn, "* OK ready\r\n")
+		time.Sleep(time.Second * 5)
+	}()
+
+	solver := &IMAPSolver{Addr: ln.Addr().String(), Username: "me@example.com", Password: "app-password", Dial: net.Dial}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := solver.Solve(ctx, Challenge{Kind: ChallengeEmail}); err != context.DeadlineExceeded || time.Since(start) > time.Second {
+		t.Fatalf("err: %v | took: %v | expected the context to cut the session short", err, time.Since(start))
+	}
+}
@@ -0,0 +1,134 @@
+package mcgo
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+)
+
+var ErrInteractiveNeedsApp = errors.New("interactive login needs the client id of your own Azure app in MsAuthOptions")
+
+// Opens url in the default browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// replaced by tests, which complete the login themselves
+var openBrowser = OpenBrowser
+
+type authRedirect struct {
+	code string
+	err  string
+}
+
+func randomURLString(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Logs in through the browser: a listener on localhost receives the redirect of the microsoft consent page, and the code
+// it carries is exchanged for tokens (with PKCE). The launcher's client ids don't allow localhost redirects, so opts has to name
+// your own Azure app, with RedirectURI a localhost uri registered for it (http://localhost:<free port>/ if empty).
+// Returns once the login completed, failed or ctx is done.
+func (account *MCaccount) MsInteractiveAuth(ctx context.Context, opts ...MsAuthOptions) error {
+	if len(opts) == 0 || opts[0].ClientID == "" {
+		return ErrInteractiveNeedsApp
+	}
+	o := msAuthOptions(opts, "")
+
+	redirect := opts[0].RedirectURI
+	addr := "127.0.0.1:0"
+	if redirect != "" {
+		u, err := url.Parse(redirect)
+		if err != nil {
+			return err
+		}
+		addr = u.Host
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if redirect == "" {
+		redirect = fmt.Sprintf("http://localhost:%d/", ln.Addr().(*net.TCPAddr).Port)
+	}
+
+	state := randomURLString(16)
+	verifier := randomURLString(32)
+	challenge := sha256.Sum256([]byte(verifier))
+
+	redirects := make(chan authRedirect, 1)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != state {
+			http.Error(w, "unexpected request", 400)
+			return
+		}
+		select {
+		case redirects <- authRedirect{code: query.Get("code"), err: query.Get("error")}:
+		default:
+		}
+		fmt.Fprint(w, "Login complete, you can close this tab.")
+	})}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	authURL := "https://login.live.com/oauth20_authorize.srf?" + url.Values{
+		"client_id":             {o.ClientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirect},
+		"scope":                 {o.Scope},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+	if err := openBrowser(authURL); err != nil {
+		return err
+	}
+
+	var result authRedirect
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result = <-redirects:
+	}
+	if result.code == "" {
+		return fmt.Errorf("interactive login failed: %v", result.err)
+	}
+
+	var token deviceTokenResponse
+	err = account.postLiveForm("https://login.live.com/oauth20_token.srf", url.Values{
+		"client_id":     {o.ClientID},
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirect},
+		"code_verifier": {verifier},
+		"scope":         {o.Scope},
+	}, &token)
+	if err != nil {
+		return err
+	}
+	if token.Error != "" {
+		return fmt.Errorf("interactive login failed: %v", token.Error)
+	}
+
+	return account.completeMsLogin(o.ClientID, token)
+}
@@ -0,0 +1,47 @@
+package mcgo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestMsInteractiveAuth(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "browser@example.com", Name: "Browser", OwnsGame: true})
+	acc := &MCaccount{Type: MsPr, Client: &Client{HTTP: srv.HTTPClient()}}
+
+	if err := acc.MsInteractiveAuth(context.Background()); err != ErrInteractiveNeedsApp {
+		t.Fatalf("err: %v | expected ErrInteractiveNeedsApp", err)
+	}
+
+	// the "browser" consents right away and follows the redirect
+	defer func(open func(string) error) { openBrowser = open }(openBrowser)
+	openBrowser = func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		query := u.Query()
+		if query.Get("code_challenge") == "" || query.Get("client_id") != "my-azure-app" {
+			t.Errorf("url: %v | expected a PKCE challenge and the own client id", authURL)
+		}
+		go func() {
+			resp, err := http.Get(query.Get("redirect_uri") + "?" + url.Values{"code": {"authcode:" + fake.Email}, "state": {query.Get("state")}}.Encode())
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	err := acc.MsInteractiveAuth(ctx, MsAuthOptions{ClientID: "my-azure-app"})
+	if err != nil || acc.Bearer != fake.Bearer || acc.Username != "Browser" || acc.MsRefreshToken == "" {
+		t.Fatalf("err: %v | bearer: %v | expected the account's bearer", err, acc.Bearer)
+	}
+}
@@ -0,0 +1,115 @@
+package mcgo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Claims of a bearer token, decoded without verifying its signature, so only use them for display and picking tokens.
+type BearerClaims struct {
+	Subject   string
+	Issuer    string
+	XUID      string // xbox user id, microsoft bearers only
+	ProfileID string // uuid of the minecraft profile the token is for, if it has one
+	IssuedAt  time.Time
+	NotBefore time.Time
+	Expires   time.Time
+	Raw       map[string]interface{}
+}
+
+type jwtClaims struct {
+	Sub      string `json:"sub"`
+	Iss      string `json:"iss"`
+	Xuid     string `json:"xuid"`
+	Iat      int64  `json:"iat"`
+	Nbf      int64  `json:"nbf"`
+	Exp      int64  `json:"exp"`
+	Profiles struct {
+		Mc string `json:"mc"`
+	} `json:"profiles"`
+}
+
+func unixOrZero(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// decodes the claims of a JWT bearer
+func DecodeBearer(bearer string) (BearerClaims, error) {
+	parts := strings.Split(bearer, ".")
+	if len(parts) != 3 {
+		return BearerClaims{}, errors.New("bearer is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return BearerClaims{}, err
+	}
+
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return BearerClaims{}, err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return BearerClaims{}, err
+	}
+
+	return BearerClaims{
+		Subject:   claims.Sub,
+		Issuer:    claims.Iss,
+		XUID:      claims.Xuid,
+		ProfileID: claims.Profiles.Mc,
+		IssuedAt:  unixOrZero(claims.Iat),
+		NotBefore: unixOrZero(claims.Nbf),
+		Expires:   unixOrZero(claims.Exp),
+		Raw:       raw,
+	}, nil
+}
+
+// Returns the bearer that expires last. Bearers that can't be decoded or have already expired are skipped.
+func FreshestBearer(bearers []string) (string, bool) {
+	var (
+		best        string
+		bestExpires time.Time
+	)
+	for _, bearer := range bearers {
+		claims, err := DecodeBearer(bearer)
+		if err != nil || claims.Expires.IsZero() || time.Now().After(claims.Expires) {
+			continue
+		}
+		if claims.Expires.After(bestExpires) {
+			best, bestExpires = bearer, claims.Expires
+		}
+	}
+	return best, best != ""
+}
+
+// decodes the claims of the account's current bearer, no requests are made
+func (account *MCaccount) TokenInfo() (BearerClaims, error) {
+	return DecodeBearer(account.currentBearer())
+}
+
+// returns when the current bearer expires, zero if it's not a JWT or has no expiry
+func (account *MCaccount) TokenExpires() time.Time {
+	claims, err := account.TokenInfo()
+	if err != nil {
+		return time.Time{}
+	}
+	return claims.Expires
+}
+
+func (account *MCaccount) updateExpiry() {
+	claims, err := DecodeBearer(account.Bearer)
+	if err != nil {
+		account.ExpiresAt = time.Time{}
+		return
+	}
+	account.ExpiresAt = claims.Expires
+}
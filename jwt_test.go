@@ -0,0 +1,58 @@
+package mcgo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestDecodeBearer(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	bearer := testBearer(fmt.Sprintf(`{"xuid": "2535", "sub": "abc", "iss": "authentication", "profiles": {"mc": "uuid"}, "exp": %d, "iat": 100}`, exp))
+
+	claims, err := DecodeBearer(bearer)
+	if err != nil || claims.XUID != "2535" || claims.Issuer != "authentication" || claims.ProfileID != "uuid" || claims.Expires.Unix() != exp || claims.IssuedAt.Unix() != 100 {
+		t.Fatalf("err: %v | claims: %+v", err, claims)
+	}
+
+	if _, err := DecodeBearer("opaque"); err == nil {
+		t.Fatal("expected error for non JWT bearer")
+	}
+}
+
+func TestFreshestBearer(t *testing.T) {
+	soon := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(time.Minute).Unix()))
+	later := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(time.Hour).Unix()))
+	expired := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(-time.Hour).Unix()))
+
+	if best, ok := FreshestBearer([]string{soon, "opaque", later, expired}); !ok || best != later {
+		t.Fatalf("best: %v | expected the later bearer", best)
+	}
+	if _, ok := FreshestBearer([]string{expired}); ok {
+		t.Fatal("expected no fresh bearer")
+	}
+}
+
+func TestTokenExpires(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	exp := time.Now().Add(time.Hour).Unix()
+	fake := srv.AddAccount(mcgotest.Account{Email: "jwt@example.com", Password: "pw", Bearer: testBearer(fmt.Sprintf(`{"exp": %d, "iat": 100}`, exp)), Name: "Jwt", OwnsGame: true})
+	acc := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Client: &Client{HTTP: srv.HTTPClient()}}
+	if err := acc.MojangAuthenticate(); err != nil || acc.ExpiresAt.Unix() != exp {
+		t.Fatalf("err: %v | expires at: %v | expected ExpiresAt to be set from the bearer", err, acc.ExpiresAt)
+	}
+
+	info, err := acc.TokenInfo()
+	if err != nil || info.IssuedAt.Unix() != 100 || acc.TokenExpires().Unix() != exp {
+		t.Fatalf("err: %v | info: %+v", err, info)
+	}
+
+	acc.Bearer = "opaque"
+	if !acc.TokenExpires().IsZero() {
+		t.Fatal("expected no expiry for an opaque bearer")
+	}
+}
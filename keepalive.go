@@ -0,0 +1,120 @@
+package mcgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// guards creating the auth locks of accounts
+var authLocksMu sync.Mutex
+
+// Returns the lock guarding the auth fields of accounts refreshed in the background, created on first use.
+// It lives in the account, so it's freed with it. Copies taken afterwards share it, which only makes them wait on each other.
+func (account *MCaccount) authLock() *sync.RWMutex {
+	authLocksMu.Lock()
+	defer authLocksMu.Unlock()
+	if account.auth == nil {
+		account.auth = &sync.RWMutex{}
+	}
+	return account.auth
+}
+
+func (account *MCaccount) currentBearer() string {
+	lock := account.authLock()
+	lock.RLock()
+	defer lock.RUnlock()
+	return account.Bearer
+}
+
+// copy of the account taken under the auth lock, so a concurrent refresh can't be read half applied
+func (account *MCaccount) snapshot() MCaccount {
+	lock := account.authLock()
+	lock.RLock()
+	defer lock.RUnlock()
+	return *account
+}
+
+// copies the result of an authentication into account
+func (account *MCaccount) applyAuth(from *MCaccount) {
+	lock := account.authLock()
+	lock.Lock()
+	defer lock.Unlock()
+	account.Bearer = from.Bearer
+	account.ExpiresAt = from.ExpiresAt
+	account.ClientToken = from.ClientToken
+	account.MsRefreshToken = from.MsRefreshToken
+	account.MsClientID = from.MsClientID
+	account.XblToken = from.XblToken
+	account.XblUserHash = from.XblUserHash
+	account.Username = from.Username
+	account.UUID = from.UUID
+	account.Authenticated = from.Authenticated
+}
+
+// Authenticates a copy of the account and applies the new token, so name changes waiting on the account keep working meanwhile.
+// Accounts with a refresh token (yggdrasil client token, microsoft refresh token) are refreshed first, which needs no password,
+// security answers or interaction.
+func (account *MCaccount) reauthenticate() error {
+	working := account.snapshot()
+	if !working.refresh() {
+		if err := working.Authenticate(); err != nil {
+			return err
+		}
+	}
+	account.applyAuth(&working)
+	return nil
+}
+
+// tries to renew the bearer without credentials, returns true if it worked
+func (account *MCaccount) refresh() bool {
+	switch {
+	case account.Type == Mj && account.ClientToken != "":
+		return account.Refresh() == nil
+	case account.MsRefreshToken != "":
+		return account.RefreshMsToken() == nil
+	}
+	return false
+}
+
+// returns true if ValidateBearer reports the current bearer as no longer valid, failed checks don't count
+func (account *MCaccount) bearerInvalid() bool {
+	working := account.snapshot()
+	_, err := working.ValidateBearer()
+	var invalid *BearerInvalidError
+	return errors.As(err, &invalid)
+}
+
+// Checks the bearer every interval until ctx is done, re-authenticating when it stopped working or expires before the next check.
+// Refreshes are reported to handler, a failed refresh with high priority, so a human has time to step in before a long scheduled snipe.
+func (account *MCaccount) KeepAlive(ctx context.Context, interval time.Duration, handler EventHandler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		needsRefresh := false
+		if claims, err := DecodeBearer(account.currentBearer()); err == nil && !claims.Expires.IsZero() && time.Until(claims.Expires) < interval*2 {
+			needsRefresh = true
+		} else if account.bearerInvalid() {
+			needsRefresh = true
+		}
+
+		if !needsRefresh {
+			continue
+		}
+
+		if err := account.reauthenticate(); err != nil {
+			handler(account.newEvent(EventTokenRefreshFailed, PriorityHigh, fmt.Sprintf("failed to refresh bearer: %v", err)))
+			continue
+		}
+		handler(account.newEvent(EventTokenRefreshed, PriorityNormal, "bearer refreshed"))
+	}
+}
@@ -0,0 +1,44 @@
+package mcgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestKeepAlive(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "keep@example.com", Password: "pw", Name: "Kept", OwnsGame: true})
+	acc := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Bearer: "revoked", Client: &Client{HTTP: srv.HTTPClient()}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan Event, 1)
+	go acc.KeepAlive(ctx, time.Millisecond*10, func(e Event) {
+		events <- e
+		cancel()
+	})
+
+	select {
+	case e := <-events:
+		if e.Type != EventTokenRefreshed || acc.currentBearer() != fake.Bearer {
+			t.Fatalf("event: %+v | bearer: %v | expected refresh to the working bearer", e, acc.currentBearer())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("bearer was not refreshed")
+	}
+}
+
+func TestAuthLockLivesInAccount(t *testing.T) {
+	acc := &MCaccount{}
+	lock := acc.authLock()
+	if acc.authLock() != lock || acc.auth != lock {
+		t.Fatal("expected the lock to be created once and kept in the account")
+	}
+	if other := (&MCaccount{}).authLock(); other == lock {
+		t.Fatal("expected separate accounts to have separate locks")
+	}
+}
@@ -0,0 +1,33 @@
+//go:build keychain
+// +build keychain
+
+package mcgo
+
+import (
+	"bytes"
+	"os/exec"
+)
+
+// CredentialStore backed by the OS keychain: the login keychain on macOS, the Windows Credential Manager, and everywhere else
+// the Secret Service (gnome-keyring, KWallet) through secret-tool. Only available when building with the keychain build tag.
+type Keychain struct {
+	Service string // name the entries are grouped under, "mcgo" if empty
+}
+
+func (k Keychain) service() string {
+	if k.Service == "" {
+		return "mcgo"
+	}
+	return k.Service
+}
+
+// runs a keychain tool, stdin keeps secrets out of the process list. Replaced by tests.
+var runKeychainTool = func(stdin string, name string, args ...string) (string, int, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewBufferString(stdin)
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return string(out), exitErr.ExitCode(), nil
+	}
+	return string(out), 0, err
+}
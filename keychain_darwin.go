@@ -0,0 +1,51 @@
+//go:build keychain && darwin
+// +build keychain,darwin
+
+package mcgo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// exit status of security when the item doesn't exist
+const errSecItemNotFound = 44
+
+func (k Keychain) Get(key string) (string, error) {
+	out, code, err := runKeychainTool("", "security", "find-generic-password", "-s", k.service(), "-a", key, "-w")
+	if err != nil {
+		return "", err
+	}
+	switch code {
+	case 0:
+		return strings.TrimSuffix(out, "\n"), nil
+	case errSecItemNotFound:
+		return "", ErrCredentialNotFound
+	}
+	return "", fmt.Errorf("security find-generic-password exited with %v", code)
+}
+
+// the command is passed to security's interactive mode, so the secret never shows up in the arguments of a process
+func (k Keychain) Set(key, secret string) error {
+	command := fmt.Sprintf("add-generic-password -U -s %v -a %v -w %v\n", strconv.Quote(k.service()), strconv.Quote(key), strconv.Quote(secret))
+	_, code, err := runKeychainTool(command, "security", "-i")
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("security add-generic-password exited with %v", code)
+	}
+	return nil
+}
+
+func (k Keychain) Delete(key string) error {
+	_, code, err := runKeychainTool("", "security", "delete-generic-password", "-s", k.service(), "-a", key)
+	if err != nil {
+		return err
+	}
+	if code != 0 && code != errSecItemNotFound {
+		return fmt.Errorf("security delete-generic-password exited with %v", code)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+//go:build keychain && !darwin && !windows
+// +build keychain,!darwin,!windows
+
+package mcgo
+
+import "fmt"
+
+// secret-tool exits with 1 for a lookup without a match, and also for a missing keyring, so an empty result is treated as not found
+func (k Keychain) Get(key string) (string, error) {
+	out, code, err := runKeychainTool("", "secret-tool", "lookup", "service", k.service(), "account", key)
+	if err != nil {
+		return "", err
+	}
+	if code == 1 && out == "" {
+		return "", ErrCredentialNotFound
+	}
+	if code != 0 {
+		return "", fmt.Errorf("secret-tool lookup exited with %v", code)
+	}
+	return out, nil
+}
+
+func (k Keychain) Set(key, secret string) error {
+	_, code, err := runKeychainTool(secret, "secret-tool", "store", "--label="+k.service()+" "+key, "service", k.service(), "account", key)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("secret-tool store exited with %v", code)
+	}
+	return nil
+}
+
+func (k Keychain) Delete(key string) error {
+	_, code, err := runKeychainTool("", "secret-tool", "clear", "service", k.service(), "account", key)
+	if err != nil {
+		return err
+	}
+	// clear exits with 1 when nothing matched
+	if code != 0 && code != 1 {
+		return fmt.Errorf("secret-tool clear exited with %v", code)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build keychain && !darwin && !windows
+// +build keychain,!darwin,!windows
+
+package mcgo
+
+import "testing"
+
+func TestKeychainSecretService(t *testing.T) {
+	defer func(run func(string, string, ...string) (string, int, error)) { runKeychainTool = run }(runKeychainTool)
+
+	// fake secret-tool keyed by the service and account attributes
+	secrets := map[string]string{}
+	runKeychainTool = func(stdin string, name string, args ...string) (string, int, error) {
+		if name != "secret-tool" {
+			t.Fatalf("ran %v | expected secret-tool", name)
+		}
+		switch args[0] {
+		case "store":
+			secrets[args[3]+"/"+args[5]] = stdin
+		case "lookup":
+			secret, ok := secrets[args[2]+"/"+args[4]]
+			if !ok {
+				return "", 1, nil
+			}
+			return secret, 0, nil
+		case "clear":
+			delete(secrets, args[2]+"/"+args[4])
+		}
+		return "", 0, nil
+	}
+
+	k := Keychain{Service: "test"}
+	if _, err := k.Get("me:password"); err != ErrCredentialNotFound {
+		t.Fatalf("err: %v | expected not found", err)
+	}
+	if err := k.Set("me:password", "pw"); err != nil {
+		t.Fatal(err)
+	}
+	if secret, err := k.Get("me:password"); err != nil || secret != "pw" || secrets["test/me:password"] != "pw" {
+		t.Fatalf("err: %v | secret: %q | expected the secret stored under the service", err, secret)
+	}
+	if err := k.Delete("me:password"); err != nil || len(secrets) != 0 {
+		t.Fatalf("err: %v | secrets: %v | expected the secret to be removed", err, secrets)
+	}
+}
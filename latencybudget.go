@@ -0,0 +1,99 @@
+package mcgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// probe connections opened to calibrate a burst with a latency budget
+const calibrationProbes = 5
+
+// One way latency to api.minecraftservices.com measured before a burst, estimated from the handshakes of probe connections.
+type Calibration struct {
+	Samples int
+	Latency time.Duration // median
+	Jitter  time.Duration // spread between the fastest and slowest probe
+
+	HTTP3 *HTTP3Calibration `json:",omitempty"` // the same over QUIC with BurstOptions.HTTP3Probe, to compare against TCP+TLS
+}
+
+// One way latency to an HTTP/3 endpoint, estimated from QUIC handshakes. It's only measured, name changes are still sent over TCP.
+type HTTP3Calibration struct {
+	Addr    string
+	Samples int
+	Latency time.Duration // median
+	Jitter  time.Duration
+	Error   string `json:",omitempty"` // why the probe failed, e.g. the endpoint doesn't answer QUIC or the quic build tag is missing
+}
+
+// Returned by HTTP/3 probes of builds without the quic build tag.
+var ErrHTTP3Unavailable = errors.New("http/3 probes need the quic build tag")
+
+// timeout of each QUIC handshake of an HTTP/3 probe, endpoints without HTTP/3 usually drop the packets instead of refusing them
+const http3ProbeTimeout = time.Second * 3
+
+// Returned for every request of a burst that wasn't sent because the calibration exceeded MaxLatency or MaxJitter.
+type LatencyBudgetError struct {
+	Calibration Calibration
+	MaxLatency  time.Duration
+	MaxJitter   time.Duration
+}
+
+func (e *LatencyBudgetError) Error() string {
+	return fmt.Sprintf("measured latency %v (jitter %v) exceeds the budget of %v (jitter %v), the burst was not sent",
+		e.Calibration.Latency, e.Calibration.Jitter, e.MaxLatency, e.MaxJitter)
+}
+
+func (opts BurstOptions) hasLatencyBudget() bool {
+	return opts.MaxLatency > 0 || opts.MaxJitter > 0
+}
+
+func (opts BurstOptions) withinLatencyBudget(c Calibration) bool {
+	return (opts.MaxLatency <= 0 || c.Latency <= opts.MaxLatency) && (opts.MaxJitter <= 0 || c.Jitter <= opts.MaxJitter)
+}
+
+// Opens probes connections one after another. A TLS handshake takes about two round trips, so a fourth of it is roughly one way.
+func (account *MCaccount) calibrate(probes int) (Calibration, error) {
+	var latencies []time.Duration
+	for i := 0; i < probes; i++ {
+		start := time.Now()
+		conn, err := account.dialer().Dial("tcp", "api.minecraftservices.com:443")
+		if err != nil {
+			return Calibration{}, err
+		}
+		handshake := time.Since(start)
+		conn.Close()
+		latencies = append(latencies, time.Duration(math.Round(float64(handshake)/4)))
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return Calibration{
+		Samples: len(latencies),
+		Latency: latencies[len(latencies)/2],
+		Jitter:  latencies[len(latencies)-1] - latencies[0],
+	}, nil
+}
+
+// Opens probes QUIC connections to addr one after another. The QUIC handshake takes one round trip, so half of it is roughly one way.
+func calibrateHTTP3(addr string, probes int) *HTTP3Calibration {
+	c := &HTTP3Calibration{Addr: addr}
+	var latencies []time.Duration
+	for i := 0; i < probes; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), http3ProbeTimeout)
+		handshake, err := probeHTTP3(ctx, addr, nil)
+		cancel()
+		if err != nil {
+			c.Error = err.Error()
+			return c
+		}
+		latencies = append(latencies, time.Duration(math.Round(float64(handshake)/2)))
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	c.Samples = len(latencies)
+	c.Latency = latencies[len(latencies)/2]
+	c.Jitter = latencies[len(latencies)-1] - latencies[0]
+	return c
+}
@@ -0,0 +1,98 @@
+package mcgo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type DiagnosticCode string
+
+const (
+	DiagMissingType       DiagnosticCode = "missing_type"
+	DiagUnknownType       DiagnosticCode = "unknown_type"
+	DiagEmailWhitespace   DiagnosticCode = "email_whitespace"
+	DiagDuplicateAccount  DiagnosticCode = "duplicate_account"
+	DiagSqAnswersCount    DiagnosticCode = "sq_answers_count"
+	DiagMissingCredential DiagnosticCode = "missing_credentials"
+	DiagBearerNoExpiry    DiagnosticCode = "bearer_no_expiry"
+	DiagBearerExpired     DiagnosticCode = "bearer_expired"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// A problem found by Lint, Index is the position of the account in the linted slice.
+type Diagnostic struct {
+	Index    int            `json:"index"`
+	Email    string         `json:"email"`
+	Code     DiagnosticCode `json:"code"`
+	Severity Severity       `json:"severity"`
+	Message  string         `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("account %v (%v): %v: %v", d.Index, d.Email, d.Severity, d.Message)
+}
+
+// Checks loaded accounts for common configuration mistakes before a run. No requests are made.
+func Lint(accounts []*MCaccount) []Diagnostic {
+	var diags []Diagnostic
+	seen := map[string]int{}
+
+	for i, account := range accounts {
+		add := func(code DiagnosticCode, severity Severity, format string, a ...interface{}) {
+			diags = append(diags, Diagnostic{
+				Index:    i,
+				Email:    account.Email,
+				Code:     code,
+				Severity: severity,
+				Message:  fmt.Sprintf(format, a...),
+			})
+		}
+
+		switch account.Type {
+		case "":
+			add(DiagMissingType, SeverityError, "account type is not set")
+		case Ms, Mj, MsPr:
+		default:
+			add(DiagUnknownType, SeverityError, "unknown account type %q", account.Type)
+		}
+
+		if strings.IndexFunc(account.Email, unicode.IsSpace) != -1 {
+			add(DiagEmailWhitespace, SeverityError, "email contains whitespace")
+		}
+
+		key := strings.ToLower(strings.TrimSpace(account.Email))
+		if key != "" {
+			if first, ok := seen[key]; ok {
+				add(DiagDuplicateAccount, SeverityWarning, "same email as account %v", first)
+			} else {
+				seen[key] = i
+			}
+		}
+
+		if n := account.securityAnswerCount(); account.Type == Mj && n != 0 && n != 3 {
+			add(DiagSqAnswersCount, SeverityError, "%v security answers given, mojang accounts need 0 or 3", n)
+		}
+
+		if account.Bearer == "" && (account.Email == "" || account.Password == "") {
+			add(DiagMissingCredential, SeverityError, "account has no bearer and no email/password to authenticate with")
+		}
+
+		if account.Bearer != "" {
+			if claims, err := DecodeBearer(account.Bearer); err != nil || claims.Expires.IsZero() {
+				add(DiagBearerNoExpiry, SeverityWarning, "bearer has no readable expiry, it may stop working at any time")
+			} else if time.Now().After(claims.Expires) {
+				add(DiagBearerExpired, SeverityError, "bearer expired at %v", claims.Expires.Format(time.RFC3339))
+			}
+		}
+	}
+
+	return diags
+}
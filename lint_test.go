@@ -0,0 +1,41 @@
+package mcgo
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func testBearer(claims string) string {
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func TestLint(t *testing.T) {
+	valid := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(time.Hour).Unix()))
+	expired := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(-time.Hour).Unix()))
+
+	accounts := []*MCaccount{
+		{Email: "ok@example.com", Password: "pw", Type: Mj},
+		{Email: "OK@example.com ", Password: "pw", Type: Ms},
+		{Email: "sq@example.com", Password: "pw", Type: Mj, SecurityAnswers: []string{"a"}},
+		{Bearer: "opaque"},
+		{Bearer: expired, Type: Ms},
+		{Bearer: valid, Type: Ms},
+	}
+
+	codes := map[int][]DiagnosticCode{}
+	for _, d := range Lint(accounts) {
+		codes[d.Index] = append(codes[d.Index], d.Code)
+	}
+
+	expected := map[int][]DiagnosticCode{
+		1: {DiagEmailWhitespace, DiagDuplicateAccount},
+		2: {DiagSqAnswersCount},
+		3: {DiagMissingType, DiagBearerNoExpiry},
+		4: {DiagBearerExpired},
+	}
+	if fmt.Sprint(codes) != fmt.Sprint(expected) {
+		t.Fatalf("diagnostics: %v | expected: %v", codes, expected)
+	}
+}
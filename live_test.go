@@ -0,0 +1,78 @@
+package mcgo
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Live tests talk to the real Mojang, Microsoft and NameMC servers. They only run with MCGO_LIVE=1, and each account type only
+// with its credentials set: MCGO_LIVE_MJ_EMAIL and MCGO_LIVE_MJ_PASSWORD, likewise with MS and MSPR. MCGO_LIVE_BEARER
+// additionally enables TestPrename, which sends a real name change.
+const envLive = "MCGO_LIVE"
+
+func requireLive(t *testing.T) {
+	t.Helper()
+	if os.Getenv(envLive) != "1" {
+		t.Skipf("live test, set %v=1 to run", envLive)
+	}
+}
+
+// returns an unauthenticated account of accType from the MCGO_LIVE_* credentials, skipping the test if they're missing
+func liveAccount(t *testing.T, accType AccType) *MCaccount {
+	t.Helper()
+	requireLive(t)
+	prefix := "MCGO_LIVE_" + strings.ToUpper(string(accType)) + "_"
+	email, password := os.Getenv(prefix+"EMAIL"), os.Getenv(prefix+"PASSWORD")
+	if email == "" || password == "" {
+		t.Skipf("set %vEMAIL and %vPASSWORD to run with a %v account", prefix, prefix, accType)
+	}
+	return &MCaccount{Email: email, Password: password, Type: accType}
+}
+
+// auth, profile and the other read only endpoints for every account type
+func TestLive(t *testing.T) {
+	for _, accType := range []AccType{Mj, Ms, MsPr} {
+		accType := accType
+		t.Run(string(accType), func(t *testing.T) {
+			acc := liveAccount(t, accType)
+			if err := acc.Authenticate(); err != nil {
+				t.Fatal(err)
+			}
+			if valid, err := acc.ValidateBearer(); err != nil || !valid {
+				t.Fatalf("err: %v | valid: %v | expected a fresh bearer to be valid", err, valid)
+			}
+
+			caps, err := acc.Capabilities()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// prepaid accounts haven't created their profile yet
+			if accType == MsPr && (caps.HasProfile || !caps.CanCreateProfile) {
+				t.Fatalf("capabilities: %+v | expected a prepaid account without a profile", caps)
+			}
+
+			profile, err := acc.FetchProfile()
+			var reqErr *RequestError
+			switch {
+			case caps.HasProfile && (err != nil || profile.ID == ""):
+				t.Fatalf("err: %v | profile: %+v | expected the account's profile", err, profile)
+			case !caps.HasProfile && !(errors.As(err, &reqErr) && reqErr.StatusCode == 404):
+				t.Fatalf("err: %v | expected no profile", err)
+			}
+
+			if caps.HasProfile {
+				if _, err := acc.NameChangeAllowedAt(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if accType != Mj {
+				xbox, err := acc.FetchXboxProfile()
+				if err != nil || xbox.XUID == "" {
+					t.Fatalf("err: %v | xbox: %+v | expected the linked xbox profile", err, xbox)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package mcgotest
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cooldown rules the server enforces once it has a clock, the same as mcgo.NameChangeCooldown and mcgo.NameReleaseDelay.
+const (
+	NameChangeCooldown = time.Hour * 24 * 30
+	NameReleaseDelay   = time.Hour * 24 * 37
+)
+
+// Source of the server's time, satisfied by mcgo.Clock implementations.
+type Clock interface {
+	Now() time.Time
+}
+
+// A clock that only moves when told to. Use the same one for the server and the mcgo.Client under test
+// to step through cooldowns and drops without waiting.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
+
+// Makes the server keep time with clock and enforce the cooldown rules by it: a profile can change its name
+// NameChangeCooldown after it was created or last renamed (NameChangeAllowed still allows it early),
+// and a name its owner changed away from can't be claimed for NameReleaseDelay.
+// Without a clock the server uses the real time and only NameChangeAllowed and BlockName.
+func (s *Server) SetClock(clock Clock) {
+	s.mu.Lock()
+	s.clock = clock
+	s.mu.Unlock()
+}
+
+// must be called with s.mu held
+func (s *Server) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// must be called with s.mu held
+func (s *Server) nameChangeAllowed(account *Account) bool {
+	if account.NameChangeAllowed || s.clock == nil {
+		return account.NameChangeAllowed
+	}
+	last := account.ChangedAt
+	if account.CreatedAt.After(last) {
+		last = account.CreatedAt
+	}
+	return !s.now().Before(last.Add(NameChangeCooldown))
+}
+
+// must be called with s.mu held
+func (s *Server) held(name string) bool {
+	until, ok := s.released[strings.ToLower(name)]
+	return ok && s.now().Before(until)
+}
@@ -0,0 +1,69 @@
+package mcgotest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// id every signature of the fake server claims to be signed by
+const signerID = "2535416586892404"
+
+// shared by all servers, so only the first test that needs it pays for generating it
+var (
+	signingOnce sync.Once
+	signer      *rsa.PrivateKey
+)
+
+// Public key the fake signs entitlements with, generated the first time it's needed and the same for every server. Point mcgo.EntitlementKeys at it in tests.
+func (s *Server) SigningKey() *rsa.PublicKey {
+	return &s.signingKey().PublicKey
+}
+
+func (s *Server) signingKey() *rsa.PrivateKey {
+	signingOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(err)
+		}
+		signer = key
+	})
+	return signer
+}
+
+// signs claims as an RS256 JWT
+func (s *Server) signJWT(claims interface{}) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"1"}`))
+	body, _ := json.Marshal(claims)
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.signingKey(), crypto.SHA256, hash[:])
+	if err != nil {
+		panic(err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (s *Server) handleEntitlements(w http.ResponseWriter, account *Account) {
+	now := s.now()
+	items := []map[string]string{}
+	names := []map[string]string{}
+	if account.OwnsGame {
+		for _, name := range []string{"product_minecraft", "game_minecraft"} {
+			items = append(items, map[string]string{"name": name, "signature": s.signJWT(map[string]string{"name": name, "signerId": signerID})})
+			names = append(names, map[string]string{"name": name})
+		}
+	}
+	writeJSON(w, 200, map[string]interface{}{
+		"items":     items,
+		"signature": s.signJWT(map[string]interface{}{"entitlements": names, "signerId": signerID, "iat": now.Unix(), "nbf": now.Unix(), "exp": now.Add(time.Hour).Unix()}),
+		"keyId":     "1",
+	})
+}
@@ -0,0 +1,100 @@
+package mcgotest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Failure modes injected into matching requests. Each field is a probability between 0 and 1,
+// checked in the order Drop, RateLimit, Malformed; Slow delays the response and can be combined with the others.
+type Fault struct {
+	RateLimit float64       // answer with a 429
+	Slow      float64       // wait Delay before answering
+	Delay     time.Duration // how long slow responses take
+	Drop      float64       // close the connection without answering
+	Malformed float64       // answer 200 with a truncated json body
+}
+
+type faultDecision struct {
+	rateLimit bool
+	delay     time.Duration
+	drop      bool
+	malformed bool
+}
+
+// Injects f into every request whose path starts with pathPrefix, replacing any fault set for the same prefix.
+// When several prefixes match a request, the longest one is used. A zero Fault removes the injection.
+func (s *Server) SetFault(pathPrefix string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f == (Fault{}) {
+		delete(s.faults, pathPrefix)
+		return
+	}
+	s.faults[pathPrefix] = f
+}
+
+// Seeds the random source used to decide which requests fail, so runs with the same seed fail the same requests.
+func (s *Server) Seed(seed int64) {
+	s.mu.Lock()
+	s.rand.Seed(seed)
+	s.mu.Unlock()
+}
+
+// must be called with s.mu held
+func (s *Server) faultFor(path string) faultDecision {
+	var (
+		match Fault
+		best  = -1
+	)
+	for prefix, f := range s.faults {
+		if strings.HasPrefix(path, prefix) && len(prefix) > best {
+			match, best = f, len(prefix)
+		}
+	}
+	if best < 0 {
+		return faultDecision{}
+	}
+
+	var d faultDecision
+	if s.rand.Float64() < match.Slow {
+		d.delay = match.Delay
+	}
+	switch {
+	case s.rand.Float64() < match.Drop:
+		d.drop = true
+	case s.rand.Float64() < match.RateLimit:
+		d.rateLimit = true
+	case s.rand.Float64() < match.Malformed:
+		d.malformed = true
+	}
+	return d
+}
+
+// applies the decision, returns true if the request was answered (or dropped) and must not be routed
+func (d faultDecision) apply(w http.ResponseWriter) bool {
+	if d.delay > 0 {
+		time.Sleep(d.delay)
+	}
+
+	switch {
+	case d.drop:
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	case d.rateLimit:
+		w.WriteHeader(429)
+		return true
+	case d.malformed:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		w.Write([]byte(`{"id": "`))
+		return true
+	}
+	return false
+}
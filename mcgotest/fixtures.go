@@ -0,0 +1,93 @@
+package mcgotest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Mix of account states GenerateAccounts fabricates, each a fraction between 0 and 1 picked independently per account.
+type FixtureOptions struct {
+	OwnsGame    float64 // accounts that own the game
+	WithProfile float64 // of the accounts owning the game, the ones that have a profile
+	OnCooldown  float64 // of the accounts with a profile, the ones that changed their name too recently to change it again
+	Microsoft   float64 // accounts with an xbox identity (XUID and gamertag)
+}
+
+var nameSyllables = []string{"ka", "zu", "mi", "ro", "xel", "dra", "vo", "en", "li", "tor", "qua", "sy", "pex", "no", "ul", "bri"}
+
+// Fabricates n accounts with realistic looking names, bearers and cooldowns and adds them to the server, for load testing pools and
+// schedulers without real data. The accounts only exist on the fake, and the same Seed gives the same accounts.
+func (s *Server) GenerateAccounts(n int, opts FixtureOptions) []*Account {
+	s.mu.Lock()
+	now := s.now()
+	offset := len(s.accounts)
+	fixtures := make([]Account, n)
+	for i := range fixtures {
+		fixtures[i] = s.fixture(offset+i, now, opts)
+	}
+	s.mu.Unlock()
+
+	accounts := make([]*Account, n)
+	for i, fixture := range fixtures {
+		accounts[i] = s.AddAccount(fixture)
+	}
+	return accounts
+}
+
+// must be called with s.mu held
+func (s *Server) fixture(i int, now time.Time, opts FixtureOptions) Account {
+	name := ""
+	for len(name) < 6 {
+		name += nameSyllables[s.rand.Intn(len(nameSyllables))]
+	}
+	// the index keeps names unique, a capital like most real names
+	name = strings.ToUpper(name[:1]) + name[1:] + fmt.Sprint(i)
+
+	account := Account{
+		Email:    fmt.Sprintf("%v@example.com", strings.ToLower(name)),
+		Password: fmt.Sprintf("%x", s.rand.Uint64()),
+		UUID:     fmt.Sprintf("%016x%016x", s.rand.Uint64(), s.rand.Uint64()),
+	}
+	if s.rand.Float64() < opts.Microsoft {
+		account.XUID = fmt.Sprint(2535400000000000 + s.rand.Int63n(100000000000))
+		account.Gamertag = name
+	}
+
+	if s.rand.Float64() < opts.OwnsGame {
+		account.OwnsGame = true
+		if s.rand.Float64() < opts.WithProfile {
+			account.Name = name
+			account.CreatedAt = now.Add(-time.Hour * 24 * time.Duration(60+s.rand.Intn(2000)))
+			account.ChangedAt = account.CreatedAt
+			account.NameChangeAllowed = true
+			if s.rand.Float64() < opts.OnCooldown {
+				account.ChangedAt = now.Add(-time.Duration(s.rand.Int63n(int64(NameChangeCooldown))))
+				account.NameChangeAllowed = false
+			}
+		}
+	}
+
+	account.Bearer = fixtureBearer(account, now)
+	return account
+}
+
+// a bearer shaped like a minecraftservices JWT, only the fake accepts it
+func fixtureBearer(account Account, now time.Time) string {
+	claims := map[string]interface{}{
+		"sub":      account.UUID,
+		"iss":      "authentication",
+		"iat":      now.Unix(),
+		"exp":      now.Add(time.Hour * 24).Unix(),
+		"profiles": map[string]string{"mc": account.UUID},
+	}
+	if account.XUID != "" {
+		claims["xuid"] = account.XUID
+	}
+	payload, _ := json.Marshal(claims)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"HS256"}`)) + "." + encode(payload) + "." + encode([]byte("mcgotest-"+account.UUID))
+}
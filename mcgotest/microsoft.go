@@ -0,0 +1,228 @@
+package mcgotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Tokens of the fake microsoft flow name the account they belong to: "msa:<email>" for live.com access tokens,
+// "xbl:<email>" and "xsts:<email>" for the xbox live tokens exchanged from it. Authorization codes are "authcode:<email>",
+// redirect to the login's redirect uri with one to complete an interactive login. Password logins check Email and Password.
+
+// Completes the device code login with the given user code as the account with email, as if its owner entered the code.
+func (s *Server) ApproveDeviceCode(userCode, email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for device, code := range s.deviceCodes {
+		if code.userCode == userCode {
+			code.email = email
+			s.deviceCodes[device] = code
+		}
+	}
+}
+
+type deviceCode struct {
+	userCode string
+	email    string // empty until approved
+}
+
+// must be called with s.mu held
+func (s *Server) accountByEmail(email string) *Account {
+	for _, account := range s.accounts {
+		if strings.EqualFold(account.Email, email) {
+			return account
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleDeviceCode(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.deviceCodes) + 1
+	device := fmt.Sprintf("device-%d", n)
+	code := deviceCode{userCode: fmt.Sprintf("FAKE%04d", n)}
+	s.deviceCodes[device] = code
+
+	writeJSON(w, 200, map[string]interface{}{
+		"user_code":        code.userCode,
+		"device_code":      device,
+		"verification_uri": "https://www.microsoft.com/link",
+		"expires_in":       900,
+		"interval":         1,
+	})
+}
+
+// the login page of the password flow, posting back to /ppsecure/post.srf with the redirect uri to send the tokens to
+func (s *Server) handleLiveAuthorize(w http.ResponseWriter, r *http.Request) {
+	post := "https://login.live.com/ppsecure/post.srf?" + url.Values{"redirect_uri": {r.URL.Query().Get("redirect_uri")}}.Encode()
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintf(w, `<html><script>var ServerData = {sFTTag:'<input type="hidden" name="PPFT" value="fake-ppft"/>',urlPost:'%v'};</script></html>`, post)
+}
+
+func (s *Server) handleLivePost(w http.ResponseWriter, r *http.Request, body []byte) {
+	form, _ := url.ParseQuery(string(body))
+
+	s.mu.Lock()
+	account := s.accountByEmail(form.Get("login"))
+	s.mu.Unlock()
+
+	if account == nil || account.Password != form.Get("passwd") || form.Get("PPFT") != "fake-ppft" {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><title>Sign in to your Microsoft account</title></html>")
+		return
+	}
+	tokens := url.Values{"access_token": {"msa:" + account.Email}, "refresh_token": {"refresh:" + account.Email}, "expires_in": {"86400"}}
+	http.Redirect(w, r, r.URL.Query().Get("redirect_uri")+"#"+tokens.Encode(), 302)
+}
+
+func (s *Server) handleLiveToken(w http.ResponseWriter, body []byte) {
+	form, _ := url.ParseQuery(string(body))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if form.Get("grant_type") == "refresh_token" {
+		email := strings.TrimPrefix(form.Get("refresh_token"), "refresh:")
+		if email == form.Get("refresh_token") || s.accountByEmail(email) == nil {
+			writeJSON(w, 400, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, 200, map[string]interface{}{
+			"access_token":  "msa:" + email,
+			"refresh_token": "refresh:" + email,
+			"expires_in":    86400,
+		})
+		return
+	}
+
+	if form.Get("grant_type") == "authorization_code" {
+		email := strings.TrimPrefix(form.Get("code"), "authcode:")
+		if email == form.Get("code") || form.Get("code_verifier") == "" || s.accountByEmail(email) == nil {
+			writeJSON(w, 400, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, 200, map[string]interface{}{
+			"access_token":  "msa:" + email,
+			"refresh_token": "refresh:" + email,
+			"expires_in":    86400,
+		})
+		return
+	}
+
+	code, ok := s.deviceCodes[form.Get("device_code")]
+	switch {
+	case !ok:
+		writeJSON(w, 400, map[string]string{"error": "expired_token"})
+	case code.email == "":
+		writeJSON(w, 400, map[string]string{"error": "authorization_pending"})
+	default:
+		writeJSON(w, 200, map[string]interface{}{
+			"access_token":  "msa:" + code.email,
+			"refresh_token": "refresh:" + code.email,
+			"expires_in":    86400,
+		})
+	}
+}
+
+func (s *Server) handleXblAuthenticate(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		Properties struct {
+			RpsTicket string `json:"RpsTicket"`
+		} `json:"Properties"`
+	}
+	json.Unmarshal(body, &payload)
+
+	// tickets of Azure apps have a "d=" prefix
+	ticket := strings.TrimPrefix(payload.Properties.RpsTicket, "d=")
+	email := strings.TrimPrefix(ticket, "msa:")
+	if email == ticket {
+		w.WriteHeader(400)
+		return
+	}
+	writeJSON(w, 200, map[string]interface{}{
+		"Token":         "xbl:" + email,
+		"DisplayClaims": map[string]interface{}{"xui": []map[string]string{{"uhs": "1234"}}},
+	})
+}
+
+func (s *Server) handleXstsAuthorize(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		Properties struct {
+			UserTokens []string `json:"UserTokens"`
+		} `json:"Properties"`
+		RelyingParty string `json:"RelyingParty"`
+	}
+	json.Unmarshal(body, &payload)
+
+	if len(payload.Properties.UserTokens) != 1 || !strings.HasPrefix(payload.Properties.UserTokens[0], "xbl:") {
+		writeJSON(w, 401, map[string]interface{}{"XErr": 2148916233, "Message": ""})
+		return
+	}
+	email := strings.TrimPrefix(payload.Properties.UserTokens[0], "xbl:")
+
+	claims := map[string]string{"uhs": "1234"}
+	// tokens for xbox live itself also carry the xbox identity
+	if payload.RelyingParty == "http://xboxlive.com" {
+		s.mu.Lock()
+		if account := s.accountByEmail(email); account != nil {
+			claims["xid"] = account.XUID
+			claims["gtg"] = account.Gamertag
+		}
+		s.mu.Unlock()
+	}
+	writeJSON(w, 200, map[string]interface{}{
+		"Token":         "xsts:" + email,
+		"DisplayClaims": map[string]interface{}{"xui": []map[string]string{claims}},
+	})
+}
+
+func (s *Server) handleXboxProfile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var account *Account
+	auth := r.Header.Get("Authorization")
+	if i := strings.Index(auth, ";xsts:"); strings.HasPrefix(auth, "XBL3.0 x=") && i >= 0 {
+		account = s.accountByEmail(auth[i+len(";xsts:"):])
+	}
+	if account == nil {
+		w.WriteHeader(401)
+		return
+	}
+	writeJSON(w, 200, map[string]interface{}{
+		"profileUsers": []map[string]interface{}{{
+			"id":       account.XUID,
+			"settings": []map[string]string{{"id": "Gamertag", "value": account.Gamertag}},
+		}},
+	})
+}
+
+func (s *Server) handleLoginWithXbox(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		IdentityToken string `json:"identityToken"`
+	}
+	json.Unmarshal(body, &payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var account *Account
+	if i := strings.Index(payload.IdentityToken, ";xsts:"); i >= 0 {
+		account = s.accountByEmail(payload.IdentityToken[i+len(";xsts:"):])
+	}
+	if account == nil {
+		writeJSON(w, 401, map[string]string{"error": "UNAUTHORIZED", "errorMessage": "Invalid identity token"})
+		return
+	}
+	writeJSON(w, 200, map[string]interface{}{
+		"username":     account.UUID,
+		"access_token": account.Bearer,
+		"token_type":   "Bearer",
+		"expires_in":   86400,
+	})
+}
@@ -0,0 +1,684 @@
+// Package mcgotest provides a fake of the Mojang and minecraftservices APIs that mcgo talks to, for testing code built on mcgo without real accounts.
+//
+// Point an account at the server with:
+//
+//	acc.Client = &mcgo.Client{HTTP: srv.HTTPClient()}
+//	acc.Dialer = srv
+package mcgotest
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An account known to the fake server. Accounts without a Name have no profile yet.
+type Account struct {
+	Email             string
+	Password          string
+	Bearer            string
+	UUID              string
+	Name              string
+	OwnsGame          bool
+	CreatedAt         time.Time
+	ChangedAt         time.Time
+	NameChangeAllowed bool
+	SkinURL           string
+	SkinVariant       string
+	ClientToken       string // client token the bearer was issued to by /authenticate, refreshing needs it
+	XUID              string // xbox identity returned by the XBL profile API
+	Gamertag          string
+	Migrated          bool // moved to a microsoft account, yggdrasil /authenticate answers 410
+	SecurityQuestions []SecurityQuestion
+	LocationTrusted   bool              // the security questions were answered from here, set by a correct POST /user/security/location
+	Bans              map[string]string // reason by banned scope (MULTIPLAYER, REALMS), multiplayer bans also disable chat
+	ProfanityFilterOn bool
+}
+
+// A security question of a Mojang account.
+type SecurityQuestion struct {
+	ID         int // id the answer is posted with, unique per account
+	QuestionID int // id of the question text, the same for every account asked it
+	Question   string
+	Answer     string
+}
+
+// A request the fake server received.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+	Time   time.Time
+}
+
+type Server struct {
+	srv *httptest.Server
+
+	mu          sync.Mutex
+	accounts    map[string]*Account   // by bearer
+	blocked     map[string]bool       // lowercase names that are free but not claimable
+	deviceCodes map[string]deviceCode // by device code
+	faults      map[string]Fault      // by path prefix
+	released    map[string]time.Time  // lowercase names changed away from, until when they're held (with a clock)
+	vouchers    map[string]string     // email of the account that redeemed each uppercase gift code, empty if unredeemed
+	rand        *rand.Rand
+	requests    []Request
+	refreshes   int
+	clock       Clock
+}
+
+// starts a fake server, callers must Close it
+func NewServer() *Server {
+	s := &Server{
+		accounts:    map[string]*Account{},
+		blocked:     map[string]bool{},
+		released:    map[string]time.Time{},
+		deviceCodes: map[string]deviceCode{},
+		faults:      map[string]Fault{},
+		vouchers:    map[string]string{},
+		rand:        rand.New(rand.NewSource(1)),
+	}
+	s.srv = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// URL of the underlying server
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Returns an http.Client that sends requests for any host to the fake server.
+func (s *Server) HTTPClient() *http.Client {
+	return &http.Client{Transport: &rewriteTransport{server: s}}
+}
+
+// Dial opens a TLS connection to the fake server regardless of addr, so the server can be used as an mcgo.Dialer.
+func (s *Server) Dial(network, addr string) (net.Conn, error) {
+	return tls.Dial("tcp", s.srv.Listener.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+}
+
+type rewriteTransport struct {
+	server *Server
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = "https"
+	req.URL.Host = t.server.srv.Listener.Addr().String()
+	return t.server.srv.Client().Transport.RoundTrip(req)
+}
+
+// adds an account to the server, a bearer and uuid are generated if missing
+func (s *Server) AddAccount(account Account) *Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account.Bearer == "" {
+		account.Bearer = fmt.Sprintf("bearer-%d", len(s.accounts)+1)
+	}
+	if account.UUID == "" {
+		account.UUID = fmt.Sprintf("%032x", len(s.accounts)+1)
+	}
+	if account.Name != "" && account.CreatedAt.IsZero() {
+		account.CreatedAt = s.now().Add(-time.Hour * 24 * 365)
+		account.ChangedAt = account.CreatedAt
+	}
+
+	stored := account
+	s.accounts[account.Bearer] = &stored
+	return &stored
+}
+
+// returns a copy of the current state of the account with the given bearer
+func (s *Server) Account(bearer string) (Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account, ok := s.accounts[bearer]
+	if !ok {
+		return Account{}, false
+	}
+	return *account, true
+}
+
+// marks a name as recently released: nobody owns it, but it can't be claimed
+func (s *Server) BlockName(name string) {
+	s.mu.Lock()
+	s.blocked[strings.ToLower(name)] = true
+	s.mu.Unlock()
+}
+
+// returns every request received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   body,
+		Time:   s.now(),
+	})
+	fault := s.faultFor(r.URL.Path)
+	s.mu.Unlock()
+
+	if fault.apply(w) {
+		return
+	}
+
+	s.route(w, r, body)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	path := r.URL.Path
+
+	switch {
+	case r.Method == "POST" && path == "/authenticate":
+		s.handleAuthenticate(w, body)
+	case r.Method == "POST" && path == "/invalidate":
+		s.handleInvalidate(w, body)
+	case r.Method == "POST" && path == "/signout":
+		s.handleSignout(w, body)
+	case r.Method == "POST" && path == "/validate":
+		s.handleValidate(w, body)
+	case r.Method == "POST" && path == "/refresh":
+		s.handleRefresh(w, body)
+	case r.Method == "POST" && path == "/oauth20_connect.srf":
+		s.handleDeviceCode(w)
+	case r.Method == "GET" && path == "/oauth20_authorize.srf":
+		s.handleLiveAuthorize(w, r)
+	case r.Method == "POST" && path == "/ppsecure/post.srf":
+		s.handleLivePost(w, r, body)
+	case r.Method == "POST" && path == "/oauth20_token.srf":
+		s.handleLiveToken(w, body)
+	case r.Method == "POST" && path == "/user/authenticate":
+		s.handleXblAuthenticate(w, body)
+	case r.Method == "POST" && path == "/xsts/authorize":
+		s.handleXstsAuthorize(w, body)
+	case r.Method == "POST" && path == "/authentication/login_with_xbox":
+		s.handleLoginWithXbox(w, body)
+	case r.Method == "GET" && path == "/users/me/profile/settings":
+		s.handleXboxProfile(w, r)
+	case r.Method == "GET" && path == "/publickeys":
+		writeJSON(w, 200, map[string]interface{}{"profilePropertyKeys": []interface{}{}, "playerCertificateKeys": []interface{}{}})
+	case r.Method == "GET" && strings.HasPrefix(path, "/users/profiles/minecraft/"):
+		s.handlePublicLookup(w, strings.TrimPrefix(path, "/users/profiles/minecraft/"))
+	case r.Method == "POST" && path == "/profiles/minecraft":
+		s.handleBulkLookup(w, body)
+	case r.Method == "GET" && strings.HasPrefix(path, "/session/minecraft/profile/"):
+		s.handleSessionProfile(w, strings.TrimPrefix(path, "/session/minecraft/profile/"))
+	case r.Method == "GET" && strings.HasPrefix(path, "/user/profile/agent/minecraft/name/"):
+		s.handlePublicLookup(w, strings.TrimPrefix(path, "/user/profile/agent/minecraft/name/"))
+	default:
+		s.routeAuthenticated(w, r, body)
+	}
+}
+
+func (s *Server) routeAuthenticated(w http.ResponseWriter, r *http.Request, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok {
+		writeJSON(w, 401, map[string]string{"path": r.URL.Path, "errorMessage": "Unauthorized"})
+		return
+	}
+
+	path := r.URL.Path
+
+	switch {
+	case r.Method == "GET" && path == "/user/security/challenges":
+		challenges := []interface{}{}
+		for _, q := range account.SecurityQuestions {
+			challenges = append(challenges, map[string]interface{}{
+				"answer":   map[string]int{"id": q.ID},
+				"question": map[string]interface{}{"id": q.QuestionID, "question": q.Question},
+			})
+		}
+		writeJSON(w, 200, challenges)
+	case r.Method == "GET" && path == "/user/security/location":
+		if len(account.SecurityQuestions) > 0 && !account.LocationTrusted {
+			w.WriteHeader(403)
+			return
+		}
+		w.WriteHeader(204)
+	case r.Method == "POST" && path == "/user/security/location":
+		var answers []struct {
+			ID     int    `json:"id"`
+			Answer string `json:"answer"`
+		}
+		json.Unmarshal(body, &answers)
+		correct := len(answers) == len(account.SecurityQuestions)
+		for _, a := range answers {
+			found := false
+			for _, q := range account.SecurityQuestions {
+				found = found || q.ID == a.ID && q.Answer == a.Answer
+			}
+			correct = correct && found
+		}
+		if !correct {
+			writeJSON(w, 403, map[string]string{"error": "ForbiddenOperationException", "errorMessage": "At least one answer was incorrect"})
+			return
+		}
+		account.LocationTrusted = true
+		w.WriteHeader(204)
+	case r.Method == "GET" && path == "/minecraft/profile":
+		if account.Name == "" {
+			writeJSON(w, 404, map[string]string{"path": path, "error": "NOT_FOUND"})
+			return
+		}
+		writeJSON(w, 200, profileJSON(account))
+	case r.Method == "POST" && path == "/minecraft/profile":
+		s.handleCreate(w, account, body)
+	case r.Method == "POST" && path == "/minecraft/profile/skins":
+		s.handleSkin(w, account, body)
+	case r.Method == "GET" && path == "/minecraft/profile/namechange":
+		writeJSON(w, 200, map[string]interface{}{
+			"changedAt":         account.ChangedAt,
+			"createdAt":         account.CreatedAt,
+			"nameChangeAllowed": s.nameChangeAllowed(account),
+		})
+	case r.Method == "GET" && strings.HasPrefix(path, "/minecraft/profile/name/") && strings.HasSuffix(path, "/available"):
+		name := strings.TrimSuffix(strings.TrimPrefix(path, "/minecraft/profile/name/"), "/available")
+		writeJSON(w, 200, map[string]string{"status": s.availability(name)})
+	case r.Method == "PUT" && strings.HasPrefix(path, "/minecraft/profile/name/"):
+		s.handleRename(w, account, strings.TrimPrefix(path, "/minecraft/profile/name/"))
+	case r.Method == "GET" && strings.HasPrefix(path, "/productvoucher/"):
+		s.handleCheckVoucher(w, strings.TrimPrefix(path, "/productvoucher/"))
+	case r.Method == "PUT" && strings.HasPrefix(path, "/productvoucher/"):
+		s.handleRedeemVoucher(w, account, strings.TrimPrefix(path, "/productvoucher/"))
+	case r.Method == "GET" && path == "/entitlements/mcstore":
+		s.handleEntitlements(w, account)
+	case r.Method == "GET" && path == "/player/attributes":
+		writeJSON(w, 200, attributes(account))
+	case r.Method == "POST" && path == "/player/attributes":
+		var payload struct {
+			ProfanityFilterPreferences *struct {
+				ProfanityFilterOn bool `json:"profanityFilterOn"`
+			} `json:"profanityFilterPreferences"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeJSON(w, 400, map[string]string{"error": "BAD_REQUEST"})
+			return
+		}
+		if payload.ProfanityFilterPreferences != nil {
+			account.ProfanityFilterOn = payload.ProfanityFilterPreferences.ProfanityFilterOn
+		}
+		writeJSON(w, 200, attributes(account))
+	default:
+		writeJSON(w, 404, map[string]string{"path": path, "error": "NOT_FOUND"})
+	}
+}
+
+func (s *Server) handleAuthenticate(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		Username    string `json:"username"`
+		Password    string `json:"password"`
+		ClientToken string `json:"clientToken"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeJSON(w, 400, map[string]string{"error": "JsonParseException"})
+		return
+	}
+	if payload.ClientToken == "" {
+		payload.ClientToken = "mcgotest"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, account := range s.accounts {
+		if account.Email == payload.Username && account.Password == payload.Password {
+			if account.Migrated {
+				writeJSON(w, 410, map[string]string{"error": "ForbiddenOperationException", "errorMessage": "Migrated"})
+				return
+			}
+			account.ClientToken = payload.ClientToken
+			writeJSON(w, 200, map[string]interface{}{
+				"accessToken": account.Bearer,
+				"clientToken": account.ClientToken,
+				"user": map[string]interface{}{
+					"username": account.Name,
+					"id":       account.UUID,
+				},
+			})
+			return
+		}
+	}
+	writeJSON(w, 403, map[string]string{"error": "ForbiddenOperationException", "errorMessage": "Invalid credentials. Invalid username or password."})
+}
+
+// must be called with s.mu held. Gives the account a new bearer, so the old one stops working.
+func (s *Server) revoke(account *Account) {
+	delete(s.accounts, account.Bearer)
+	s.refreshes++
+	account.Bearer = fmt.Sprintf("bearer-revoked-%d", s.refreshes)
+	s.accounts[account.Bearer] = account
+}
+
+func (s *Server) handleInvalidate(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		AccessToken string `json:"accessToken"`
+	}
+	json.Unmarshal(body, &payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account, ok := s.accounts[payload.AccessToken]; ok {
+		s.revoke(account)
+	}
+	w.WriteHeader(204)
+}
+
+func (s *Server) handleSignout(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	json.Unmarshal(body, &payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := s.accountByEmail(payload.Username)
+	if account == nil || account.Password != payload.Password {
+		writeJSON(w, 403, map[string]string{"error": "ForbiddenOperationException", "errorMessage": "Invalid credentials. Invalid username or password."})
+		return
+	}
+	s.revoke(account)
+	w.WriteHeader(204)
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		AccessToken string `json:"accessToken"`
+	}
+	json.Unmarshal(body, &payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.accounts[payload.AccessToken]; !ok {
+		writeJSON(w, 403, map[string]string{"error": "ForbiddenOperationException", "errorMessage": "Invalid token"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+// issues a new bearer for a bearer and the client token it was authenticated with, the old bearer stops working
+func (s *Server) handleRefresh(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		AccessToken string `json:"accessToken"`
+		ClientToken string `json:"clientToken"`
+	}
+	json.Unmarshal(body, &payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[payload.AccessToken]
+	if !ok || payload.ClientToken == "" || payload.ClientToken != account.ClientToken {
+		writeJSON(w, 403, map[string]string{"error": "ForbiddenOperationException", "errorMessage": "Invalid token."})
+		return
+	}
+
+	delete(s.accounts, account.Bearer)
+	s.refreshes++
+	account.Bearer = fmt.Sprintf("%v-refreshed-%d", payload.AccessToken, s.refreshes)
+	s.accounts[account.Bearer] = account
+
+	writeJSON(w, 200, map[string]interface{}{
+		"accessToken": account.Bearer,
+		"clientToken": payload.ClientToken,
+		"user": map[string]interface{}{
+			"username": account.Name,
+			"id":       account.UUID,
+		},
+	})
+}
+
+func (s *Server) handlePublicLookup(w http.ResponseWriter, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if owner := s.owner(name); owner != nil {
+		writeJSON(w, 200, map[string]string{"id": owner.UUID, "name": owner.Name})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (s *Server) handleSessionProfile(w http.ResponseWriter, uuid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var owner *Account
+	for _, account := range s.accounts {
+		if account.Name != "" && account.UUID == uuid {
+			owner = account
+		}
+	}
+	if owner == nil {
+		w.WriteHeader(204)
+		return
+	}
+
+	textures := map[string]interface{}{}
+	if owner.SkinURL != "" {
+		skin := map[string]interface{}{"url": owner.SkinURL}
+		if owner.SkinVariant == "SLIM" {
+			skin["metadata"] = map[string]string{"model": "slim"}
+		}
+		textures["SKIN"] = skin
+	}
+	value, _ := json.Marshal(map[string]interface{}{
+		"timestamp":   s.now().UnixNano() / int64(time.Millisecond),
+		"profileId":   owner.UUID,
+		"profileName": owner.Name,
+		"textures":    textures,
+	})
+
+	writeJSON(w, 200, map[string]interface{}{
+		"id":   owner.UUID,
+		"name": owner.Name,
+		"properties": []map[string]string{
+			{"name": "textures", "value": base64.StdEncoding.EncodeToString(value)},
+		},
+	})
+}
+
+func (s *Server) handleBulkLookup(w http.ResponseWriter, body []byte) {
+	var names []string
+	if err := json.Unmarshal(body, &names); err != nil || len(names) > 10 {
+		writeJSON(w, 400, map[string]string{"error": "IllegalArgumentException"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := []map[string]string{}
+	for _, name := range names {
+		if owner := s.owner(name); owner != nil {
+			found = append(found, map[string]string{"id": owner.UUID, "name": owner.Name})
+		}
+	}
+	writeJSON(w, 200, found)
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, account *Account, body []byte) {
+	var payload struct {
+		ProfileName string `json:"profileName"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeJSON(w, 400, map[string]string{"error": "CONSTRAINT_VIOLATION"})
+		return
+	}
+
+	if account.Name != "" {
+		writeDetails(w, 400, "ALREADY_REGISTERED")
+		return
+	}
+	if !account.OwnsGame {
+		writeDetails(w, 400, "NOT_ENTITLED")
+		return
+	}
+	if status := s.availability(payload.ProfileName); status != "AVAILABLE" {
+		writeDetails(w, 400, status)
+		return
+	}
+
+	account.Name = payload.ProfileName
+	account.CreatedAt = s.now()
+	account.ChangedAt = account.CreatedAt
+	account.NameChangeAllowed = false
+	writeJSON(w, 200, profileJSON(account))
+}
+
+func (s *Server) handleSkin(w http.ResponseWriter, account *Account, body []byte) {
+	var payload struct {
+		Variant string `json:"variant"`
+		URL     string `json:"url"`
+	}
+	json.Unmarshal(body, &payload)
+
+	if account.Name == "" {
+		writeJSON(w, 404, map[string]string{"error": "NOT_FOUND"})
+		return
+	}
+	variant := strings.ToUpper(payload.Variant)
+	if payload.URL == "" || variant != "CLASSIC" && variant != "SLIM" {
+		writeJSON(w, 400, map[string]string{"error": "IllegalArgumentException"})
+		return
+	}
+
+	account.SkinURL = payload.URL
+	account.SkinVariant = variant
+	writeJSON(w, 200, profileJSON(account))
+}
+
+// player attributes of account as returned by /player/attributes
+func attributes(account *Account) map[string]interface{} {
+	bans := map[string]interface{}{}
+	for scope, reason := range account.Bans {
+		bans[scope] = map[string]interface{}{"banId": "ban-" + strings.ToLower(scope), "expires": nil, "reason": reason, "reasonMessage": reason}
+	}
+	_, multiplayerBan := account.Bans["MULTIPLAYER"]
+	_, realmsBan := account.Bans["REALMS"]
+	return map[string]interface{}{
+		"privileges": map[string]interface{}{
+			"onlineChat":        map[string]bool{"enabled": !multiplayerBan},
+			"multiplayerServer": map[string]bool{"enabled": !multiplayerBan},
+			"multiplayerRealms": map[string]bool{"enabled": !realmsBan},
+			"telemetry":         map[string]bool{"enabled": true},
+		},
+		"profanityFilterPreferences": map[string]bool{"profanityFilterOn": account.ProfanityFilterOn},
+		"banStatus":                  map[string]interface{}{"bannedScopes": bans},
+	}
+}
+
+func (s *Server) handleRename(w http.ResponseWriter, account *Account, name string) {
+	if account.Name == "" {
+		writeJSON(w, 404, map[string]string{"error": "NOT_FOUND"})
+		return
+	}
+	if !s.nameChangeAllowed(account) {
+		writeJSON(w, 403, map[string]string{"error": "FORBIDDEN", "errorMessage": "Name change not allowed"})
+		return
+	}
+	switch s.availability(name) {
+	case "DUPLICATE":
+		writeDetails(w, 403, "DUPLICATE")
+		return
+	case "NOT_ALLOWED":
+		writeDetails(w, 400, "NOT_ALLOWED")
+		return
+	}
+
+	if s.clock != nil {
+		s.released[strings.ToLower(account.Name)] = s.now().Add(NameReleaseDelay)
+	}
+	account.Name = name
+	account.ChangedAt = s.now()
+	account.NameChangeAllowed = false
+	writeJSON(w, 200, profileJSON(account))
+}
+
+// must be called with s.mu held
+func (s *Server) owner(name string) *Account {
+	for _, account := range s.accounts {
+		if account.Name != "" && strings.EqualFold(account.Name, name) {
+			return account
+		}
+	}
+	return nil
+}
+
+// must be called with s.mu held
+func (s *Server) availability(name string) string {
+	if len(name) < 3 || len(name) > 16 {
+		return "NOT_ALLOWED"
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return "NOT_ALLOWED"
+		}
+	}
+	if s.owner(name) != nil || s.blocked[strings.ToLower(name)] || s.held(name) {
+		return "DUPLICATE"
+	}
+	return "AVAILABLE"
+}
+
+func profileJSON(account *Account) map[string]interface{} {
+	skins := []map[string]string{}
+	if account.SkinURL != "" {
+		variant := account.SkinVariant
+		if variant == "" {
+			variant = "CLASSIC"
+		}
+		skins = append(skins, map[string]string{"id": account.UUID, "state": "ACTIVE", "url": account.SkinURL, "variant": variant})
+	}
+	return map[string]interface{}{
+		"id":    account.UUID,
+		"name":  account.Name,
+		"skins": skins,
+		"capes": []interface{}{},
+	}
+}
+
+func writeDetails(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]interface{}{
+		"path":    "/minecraft/profile",
+		"error":   "CONSTRAINT_VIOLATION",
+		"details": map[string]string{"status": detail},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	b, _ := json.Marshal(v)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
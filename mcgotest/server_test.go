@@ -0,0 +1,116 @@
+package mcgotest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFaults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.HTTPClient()
+
+	srv.SetFault("/users/profiles/minecraft/", Fault{RateLimit: 1})
+	resp, err := client.Get("https://api.mojang.com/users/profiles/minecraft/test")
+	if err != nil || resp.StatusCode != 429 {
+		t.Fatalf("err: %v | resp: %v | expected status 429", err, resp)
+	}
+	resp.Body.Close()
+
+	srv.SetFault("/users/profiles/minecraft/", Fault{Malformed: 1})
+	resp, err = client.Get("https://api.mojang.com/users/profiles/minecraft/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 200 || string(body) != `{"id": "` {
+		t.Fatalf("status: %v | body: %s | expected truncated body", resp.StatusCode, body)
+	}
+
+	srv.SetFault("/users/profiles/minecraft/", Fault{Drop: 1})
+	if _, err = client.Get("https://api.mojang.com/users/profiles/minecraft/test"); err == nil {
+		t.Fatal("expected error for dropped connection")
+	}
+
+	srv.SetFault("/users/profiles/minecraft/", Fault{Slow: 1, Delay: time.Millisecond * 50})
+	start := time.Now()
+	resp, err = client.Get("https://api.mojang.com/users/profiles/minecraft/test")
+	if err != nil || resp.StatusCode != 204 || time.Since(start) < time.Millisecond*50 {
+		t.Fatalf("err: %v | resp: %v | took: %v | expected slow 204", err, resp, time.Since(start))
+	}
+	resp.Body.Close()
+
+	srv.SetFault("/users/profiles/minecraft/", Fault{})
+	if len(srv.faults) != 0 {
+		t.Fatal("expected zero fault to remove injection")
+	}
+}
+
+func TestFaultProbability(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Seed(42)
+	srv.SetFault("/", Fault{RateLimit: 0.5})
+
+	limited := 0
+	for i := 0; i < 1000; i++ {
+		if srv.faultFor("/minecraft/profile").rateLimit {
+			limited++
+		}
+	}
+	if limited < 400 || limited > 600 {
+		t.Fatalf("rate limited %v of 1000 requests, expected around 500", limited)
+	}
+}
+
+func TestGenerateAccounts(t *testing.T) {
+	generate := func() []*Account {
+		srv := NewServer()
+		defer srv.Close()
+		srv.Seed(7)
+		return srv.GenerateAccounts(200, FixtureOptions{OwnsGame: 0.8, WithProfile: 0.5, OnCooldown: 0.5, Microsoft: 0.5})
+	}
+
+	accounts := generate()
+	owned, profiles, cooldowns := 0, 0, 0
+	bearers := map[string]bool{}
+	for _, account := range accounts {
+		bearers[account.Bearer] = true
+		if account.OwnsGame {
+			owned++
+		}
+		if account.Name != "" {
+			profiles++
+			if len(account.Name) > 16 || account.Email == "" {
+				t.Fatalf("account: %+v | expected a valid name and an email", account)
+			}
+		}
+		if !account.NameChangeAllowed && account.Name != "" {
+			cooldowns++
+			if time.Since(account.ChangedAt) > NameChangeCooldown {
+				t.Fatalf("changed at: %v | expected accounts on cooldown to have changed recently", account.ChangedAt)
+			}
+		}
+	}
+	if len(bearers) != 200 || owned < 140 || owned > 180 || profiles < 50 || cooldowns == 0 || cooldowns == profiles {
+		t.Fatalf("bearers: %v | owned: %v | profiles: %v | cooldowns: %v | expected the requested mix", len(bearers), owned, profiles, cooldowns)
+	}
+
+	if again := generate(); again[10].Email != accounts[10].Email || again[10].UUID != accounts[10].UUID {
+		t.Fatalf("accounts: %v, %v | expected the same seed to give the same accounts", again[10].Email, accounts[10].Email)
+	}
+
+	srv := NewServer()
+	defer srv.Close()
+	fixture := srv.GenerateAccounts(1, FixtureOptions{OwnsGame: 1, WithProfile: 1})[0]
+	req, _ := http.NewRequest("GET", "https://api.minecraftservices.com/minecraft/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+fixture.Bearer)
+	resp, err := srv.HTTPClient().Do(req)
+	if err != nil || resp.StatusCode != 200 {
+		t.Fatalf("err: %v | resp: %v | expected the fixture to be served", err, resp)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,49 @@
+package mcgotest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// adds an unredeemed gift code, redeeming it makes the account own the game
+func (s *Server) AddVoucher(code string) {
+	s.mu.Lock()
+	s.vouchers[strings.ToUpper(code)] = ""
+	s.mu.Unlock()
+}
+
+// returns the email of the account that redeemed code, empty if it's unredeemed, and false if the server doesn't know the code
+func (s *Server) Voucher(code string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	redeemedBy, ok := s.vouchers[strings.ToUpper(code)]
+	return redeemedBy, ok
+}
+
+func (s *Server) handleRedeemVoucher(w http.ResponseWriter, account *Account, code string) {
+	code = strings.ToUpper(code)
+	redeemedBy, ok := s.vouchers[code]
+	switch {
+	case !ok:
+		writeJSON(w, 404, map[string]string{"path": "/productvoucher/" + code, "error": "NOT_FOUND", "errorMessage": "Invalid code"})
+	case redeemedBy != "":
+		writeJSON(w, 409, map[string]string{"path": "/productvoucher/" + code, "error": "ALREADY_REDEEMED", "errorMessage": "Code already redeemed"})
+	default:
+		s.vouchers[code] = account.Email
+		account.OwnsGame = true
+		writeJSON(w, 200, map[string]string{"code": code, "status": "REDEEMED"})
+	}
+}
+
+func (s *Server) handleCheckVoucher(w http.ResponseWriter, code string) {
+	code = strings.ToUpper(code)
+	redeemedBy, ok := s.vouchers[code]
+	switch {
+	case !ok:
+		writeJSON(w, 404, map[string]string{"path": "/productvoucher/" + code, "error": "NOT_FOUND", "errorMessage": "Invalid code"})
+	case redeemedBy != "":
+		writeJSON(w, 200, map[string]string{"code": code, "status": "REDEEMED"})
+	default:
+		writeJSON(w, 200, map[string]string{"code": code, "status": "ACTIVE"})
+	}
+}
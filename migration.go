@@ -0,0 +1,40 @@
+package mcgo
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrMigrated = errors.New("the mojang account was migrated to microsoft, log in with a microsoft flow")
+
+type MigrationStatus string
+
+const (
+	NotMigrated MigrationStatus = "not-migrated" // still a Mojang account, yggdrasil works
+	Migrated    MigrationStatus = "migrated"     // moved to a microsoft account, authenticate with MicrosoftAuthenticate or MsDeviceCodeAuth
+)
+
+// Returns whether the account was migrated to microsoft, so tools can switch to a microsoft flow. Ms and MsPr accounts are migrated
+// without a request, Mj accounts are checked by logging in with yggdrasil (which needs the password): if that works the account
+// gets the new bearer (yggdrasil logins invalidate the old one), applied under the auth lock like a KeepAlive refresh so requests
+// running meanwhile never see it half written.
+func (account *MCaccount) MigrationStatus() (MigrationStatus, error) {
+	switch account.Type {
+	case Ms, MsPr:
+		return Migrated, nil
+	case Mj:
+	default:
+		return "", fmt.Errorf("can't check the migration of account with type %q", account.Type)
+	}
+
+	working := account.snapshot()
+	err := working.authenticate()
+	if err == ErrMigrated {
+		return Migrated, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	account.applyAuth(&working)
+	return NotMigrated, nil
+}
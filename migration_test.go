@@ -0,0 +1,55 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestMigrationStatus(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	mojang := newFakeAccount(srv, mcgotest.Account{Email: "mojang@example.com", Password: "pw", Name: "Legacy", OwnsGame: true})
+	mojang.Type = Mj
+	mojang.Bearer = ""
+
+	// requests reading the bearer while the check replaces it, for the race detector
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				mojang.currentBearer()
+			}
+		}
+	}()
+	status, err := mojang.MigrationStatus()
+	close(stop)
+	<-done
+	if err != nil || status != NotMigrated || mojang.Bearer == "" {
+		t.Fatalf("err: %v | status: %v | expected a mojang account with a new bearer", err, status)
+	}
+
+	moved := newFakeAccount(srv, mcgotest.Account{Email: "moved@example.com", Password: "pw", OwnsGame: true, Migrated: true})
+	moved.Type = Mj
+	if status, err := moved.MigrationStatus(); err != nil || status != Migrated {
+		t.Fatalf("err: %v | status: %v | expected a migrated account", err, status)
+	}
+	if err := moved.MojangAuthenticate(); err != ErrMigrated {
+		t.Fatalf("err: %v | expected yggdrasil logins of migrated accounts to return ErrMigrated", err)
+	}
+
+	wrong := &MCaccount{Email: "mojang@example.com", Password: "wrong", Type: Mj, Client: &Client{HTTP: srv.HTTPClient()}}
+	if status, err := wrong.MigrationStatus(); err == nil || status != "" {
+		t.Fatalf("err: %v | status: %v | expected wrong credentials to fail the check", err, status)
+	}
+
+	if status, err := (&MCaccount{Type: Ms}).MigrationStatus(); err != nil || status != Migrated {
+		t.Fatalf("err: %v | status: %v | expected microsoft accounts to be migrated", err, status)
+	}
+}
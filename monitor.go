@@ -0,0 +1,132 @@
+package mcgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Fetches the account's profile and player attributes and diffs them against the profile seen by the previous check
+// (or, on the first check, what is already known about the account: username, uuid, last seen skin).
+// Every change that mcgo did not cause is returned as a high priority event, as it likely means someone else has access to the account.
+// The account is updated to the fetched state, so each change is only reported once.
+func (account *MCaccount) DetectSuspiciousAccess() ([]Event, error) {
+	old := account.knownProfile()
+
+	profile, err := account.FetchProfile()
+	if err != nil {
+		var reqErr *RequestError
+		if errors.As(err, &reqErr) && reqErr.StatusCode == 401 {
+			return []Event{account.rejectedBearerEvent()}, nil
+		}
+		return nil, err
+	}
+
+	// privileges are best effort, without them only the profile is compared
+	if attributes, err := account.playerAttributes(); err == nil {
+		profile.Privileges = attributes.privileges()
+	}
+
+	var events []Event
+
+	if old != nil && old.ID == profile.ID {
+		diff := DiffProfiles(*old, profile)
+
+		if diff.NameChanged && old.Name != "" {
+			events = append(events, account.newEvent(EventUsernameChanged, PriorityHigh, fmt.Sprintf("username changed from %v to %v outside of mcgo", diff.OldName, diff.NewName)))
+		}
+		if diff.SkinChanged && diff.OldSkin.URL != "" {
+			events = append(events, account.newEvent(EventSkinChanged, PriorityHigh, fmt.Sprintf("skin changed from %v to %v", describeSkin(diff.OldSkin), describeSkin(diff.NewSkin))))
+		}
+		if diff.CapeChanged {
+			events = append(events, account.newEvent(EventCapeChanged, PriorityHigh, fmt.Sprintf("cape changed from %v to %v", describeCape(diff.OldCape), describeCape(diff.NewCape))))
+		}
+		for _, change := range diff.Privileges {
+			events = append(events, account.newEvent(EventPrivilegeChanged, PriorityHigh, fmt.Sprintf("privilege %v changed from %v to %v", change.Name, change.Old, change.New)))
+		}
+	}
+
+	account.Username = profile.Name
+	account.UUID = profile.ID
+	account.LastProfile = &profile
+
+	return events, nil
+}
+
+// event for a bearer the api refused, only one refused before its expiry points at someone having signed the account out
+func (account *MCaccount) rejectedBearerEvent() Event {
+	expires := account.ExpiresAt
+	if expires.IsZero() {
+		expires = account.TokenExpires()
+	}
+	switch {
+	case expires.IsZero():
+		return account.newEvent(EventTokenInvalidated, PriorityHigh, "bearer token was rejected and its expiry is unknown")
+	case account.now().Before(expires):
+		return account.newEvent(EventTokenInvalidated, PriorityHigh, "bearer token was invalidated before it expired")
+	}
+	return account.newEvent(EventTokenExpired, PriorityNormal, fmt.Sprintf("bearer token expired at %v", expires.Format(time.RFC3339)))
+}
+
+// keeps the profile of the last check in step with a rename mcgo did, so the next check doesn't report it
+func (account *MCaccount) recordOwnName(name string) {
+	account.Username = name
+	if account.LastProfile != nil {
+		updated := *account.LastProfile
+		updated.Name = name
+		account.LastProfile = &updated
+	}
+}
+
+// like recordOwnName for requests that return the whole profile (skin changes, creates), privileges are kept from the last check
+func (account *MCaccount) recordOwnProfile(profile Profile) {
+	account.UUID = profile.ID
+	account.Username = profile.Name
+	if account.LastProfile != nil {
+		profile.Privileges = account.LastProfile.Privileges
+		account.LastProfile = &profile
+	}
+}
+
+// returns the profile of the last check, or one built from the username, uuid and skin history. Nil if nothing is known.
+func (account *MCaccount) knownProfile() *Profile {
+	if account.LastProfile != nil {
+		return account.LastProfile
+	}
+	if account.UUID == "" {
+		return nil
+	}
+
+	known := &Profile{ID: account.UUID, Name: account.Username}
+	if n := len(account.SkinHistory); n > 0 {
+		last := account.SkinHistory[n-1]
+		known.Skins = []Skin{{State: "ACTIVE", URL: last.Hash, Variant: last.Variant}}
+	}
+	return known
+}
+
+// Runs DetectSuspiciousAccess on every account each interval until ctx is done, passing events to handler.
+// Accounts whose check fails (network errors, ratelimits) are skipped until the next run.
+func Monitor(ctx context.Context, accounts []*MCaccount, interval time.Duration, handler EventHandler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, account := range accounts {
+			events, err := account.DetectSuspiciousAccess()
+			if err != nil {
+				continue
+			}
+			for _, event := range events {
+				handler(event)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package mcgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestDetectSuspiciousAccessIgnoresOwnChanges(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "watched@example.com", Name: "Watched", OwnsGame: true, NameChangeAllowed: true, SkinURL: "http://textures.minecraft.net/texture/aaa"})
+	if _, err := acc.DetectSuspiciousAccess(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := acc.ChangeName("Renamed", time.Now(), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := acc.ChangeSkin("http://textures.minecraft.net/texture/bbb", SkinOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if events, err := acc.DetectSuspiciousAccess(); err != nil || len(events) != 0 {
+		t.Fatalf("err: %v | events: %+v | expected mcgo's own rename and skin change not to be reported", err, events)
+	}
+
+	// someone else holding the bearer changes the skin
+	other := &MCaccount{Bearer: acc.Bearer, Client: &Client{HTTP: srv.HTTPClient()}}
+	if _, err := other.ChangeSkin("http://textures.minecraft.net/texture/ccc", SkinOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	events, err := acc.DetectSuspiciousAccess()
+	if err != nil || len(events) != 1 || events[0].Type != EventSkinChanged {
+		t.Fatalf("err: %v | events: %+v | expected the outside skin change to be reported", err, events)
+	}
+}
+
+func TestDetectSuspiciousAccessRejectedBearer(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "watched@example.com", Name: "Watched", OwnsGame: true})
+	acc.Bearer = "not-a-known-bearer"
+
+	for _, tc := range []struct {
+		expires  time.Time
+		want     EventType
+		priority Priority
+	}{
+		{time.Now().Add(time.Hour), EventTokenInvalidated, PriorityHigh},
+		{time.Now().Add(-time.Hour), EventTokenExpired, PriorityNormal},
+		{time.Time{}, EventTokenInvalidated, PriorityHigh},
+	} {
+		acc.ExpiresAt = tc.expires
+		events, err := acc.DetectSuspiciousAccess()
+		if err != nil || len(events) != 1 || events[0].Type != tc.want || events[0].Priority != tc.priority {
+			t.Fatalf("err: %v | events: %+v | expected a %v event for a bearer expiring at %v", err, events, tc.want, tc.expires)
+		}
+		if tc.expires.IsZero() && strings.Contains(events[0].Message, "before it expired") {
+			t.Fatalf("message: %v | expected an unknown expiry not to be reported as an early invalidation", events[0].Message)
+		}
+	}
+}
@@ -54,6 +54,8 @@ type xSTSAuthorizeResponse struct {
 	Displayclaims struct {
 		Xui []struct {
 			Uhs string `json:"uhs"`
+			Xid string `json:"xid"` // only for the xbox live relying party, as is Gtg
+			Gtg string `json:"gtg"`
 		} `json:"xui"`
 	} `json:"DisplayClaims"`
 }
@@ -80,18 +82,59 @@ type msGetMojangBearerResponse struct {
 	Foci         string `json:"foci"`
 }
 
-func (account *MCaccount) MicrosoftAuthenticate() error {
+var (
+	sFTTagRegex  = regexp.MustCompile(`sFTTag:'[^']*?value="(.+?)"`)
+	valRegex     = regexp.MustCompile(`value="(.+?)"`)
+	urlPostRegex = regexp.MustCompile(`urlPost:'(.+?)'`)
+)
+
+// grabs the PPFT value and the url the form of a login page posts to
+func loginForm(page []byte) (ppft string, urlPost string, err error) {
+	// the PPFT value is in the sFTTag input, older login pages only had it as the first value on the page
+	valueMatch := sFTTagRegex.FindSubmatch(page)
+	if valueMatch == nil {
+		valueMatch = valRegex.FindSubmatch(page)
+	}
+	urlPostMatch := urlPostRegex.FindSubmatch(page)
+	if valueMatch == nil || urlPostMatch == nil {
+		return "", "", errors.New("login page has no PPFT value or post url, the microsoft login flow probably changed")
+	}
+	return string(valueMatch[1]), string(urlPostMatch[1]), nil
+}
+
+// the transport of the account's Client (so its proxy) with the renegotiation login.live.com needs. Certificates are still verified,
+// so a proxy can't read the password and tokens. Transports that aren't an *http.Transport (fakes, wrappers) are used as they are.
+func (account *MCaccount) msTransport() http.RoundTripper {
+	rt := account.client().HTTP.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.Renegotiation = tls.RenegotiateOnceAsClient
+	return transport
+}
+
+// client id of the minecraft launcher's password login
+const msPasswordClientID = "000000004C12AE6F"
+
+// Authenticates a microsoft account with its email and password, opts can name your own Azure app to log in with
+// (it needs the implicit token flow enabled for RedirectURI), and answer the 2fa challenge of accounts with an authenticator app.
+func (account *MCaccount) MicrosoftAuthenticate(opts ...MsAuthOptions) error {
+	o := msAuthOptions(opts, msPasswordClientID)
+
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return err
 	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			Renegotiation:      tls.RenegotiateOnceAsClient,
-			InsecureSkipVerify: true},
-	}
-
 	var redirect string
 	client := &http.Client{
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
@@ -99,13 +142,17 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 			return nil
 		},
 		Jar:       jar,
-		Transport: tr,
+		Transport: account.msTransport(),
+		Timeout:   account.client().HTTP.Timeout,
 	}
-	// Grab value and urlpost
-	valRegex := regexp.MustCompile(`value="(.+?)"`)
-	urlPostRegex := regexp.MustCompile(`urlPost:'(.+?)'`)
-
-	resp, err := client.Get("https://login.live.com/oauth20_authorize.srf?client_id=000000004C12AE6F&redirect_uri=https://login.live.com/oauth20_desktop.srf&scope=service::user.auth.xboxlive.com::MBI_SSL&display=touch&response_type=token&locale=en")
+	resp, err := client.Get("https://login.live.com/oauth20_authorize.srf?" + url.Values{
+		"client_id":     {o.ClientID},
+		"redirect_uri":  {o.RedirectURI},
+		"scope":         {o.Scope},
+		"display":       {"touch"},
+		"response_type": {"token"},
+		"locale":        {"en"},
+	}.Encode())
 
 	if err != nil {
 		return err
@@ -119,8 +166,10 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 
 	// respString := string(respBytes)
 
-	value := string(valRegex.FindAllSubmatch(respBytes, -1)[0][1])
-	urlPost := string(urlPostRegex.FindAllSubmatch(respBytes, -1)[0][1])
+	value, urlPost, err := loginForm(respBytes)
+	if err != nil {
+		return err
+	}
 
 	// Sign in to microsoft
 
@@ -162,8 +211,30 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 		return errors.New("invalid credentials")
 	}
 
-	if strings.Contains(respStr, "Help us protect your account") {
-		return errors.New("2fa is enabled, which is not supported now")
+	if isEmailChallenge(respStr) {
+		code, err := o.solveChallenge(Challenge{Kind: ChallengeEmail, Email: account.Email, SentAt: account.now()})
+		if err != nil {
+			return err
+		}
+		respStr, err = postOtc(client, []byte(respStr), account.Email, code, otcEmail)
+		if err != nil {
+			return err
+		}
+		if isEmailChallenge(respStr) {
+			return errors.New("email verification code was rejected")
+		}
+	} else if isOtcChallenge(respStr) {
+		code, err := o.twoFactorCode(time.Now())
+		if err != nil {
+			return err
+		}
+		respStr, err = postOtc(client, []byte(respStr), account.Email, code, otcAuthenticator)
+		if err != nil {
+			return err
+		}
+		if isOtcChallenge(respStr) {
+			return errors.New("2fa code was rejected")
+		}
 	}
 
 	if !strings.Contains(redirect, "access_token") || redirect == urlPost {
@@ -180,6 +251,67 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 		loginData[itemSplit[0]] = v
 	}
 
+	// kept even if the xbox part fails, the refresh token is good for RefreshMsToken either way
+	account.MsRefreshToken = loginData["refresh_token"]
+	account.MsClientID = o.ClientID
+	if err := account.loginWithRpsTicket(client, rpsTicket(o.ClientID, loginData["access_token"])); err != nil {
+		return err
+	}
+
+	return account.loadIdentity()
+}
+
+// Fills in UUID and Username from the profile of a freshly authenticated account, microsoft logins only return a bearer.
+// Accounts without a profile keep both empty.
+func (account *MCaccount) loadIdentity() error {
+	profile, err := account.FetchProfile()
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode == 404 {
+		account.UUID = ""
+		account.Username = ""
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	account.UUID = profile.ID
+	account.Username = profile.Name
+	return nil
+}
+
+// runs the xbox live part of microsoft auth: the RPS ticket (a live.com access token) is exchanged for an XBL token,
+// that for an XSTS token for minecraftservices, and that for a minecraft bearer. The XBL token is kept for FetchXboxProfile.
+func (account *MCaccount) loginWithRpsTicket(client *http.Client, rpsTicket string) error {
+	xblToken, uhs, err := ExchangeRpsForXbl(client, rpsTicket)
+	if err != nil {
+		return err
+	}
+
+	xstsToken, err := ExchangeXblForXsts(client, xblToken)
+	if err != nil {
+		return err
+	}
+
+	bearer, err := LoginWithXbox(client, uhs, xstsToken)
+	if err != nil {
+		return err
+	}
+
+	account.Bearer = bearer
+	account.updateExpiry()
+	account.XblToken = xblToken
+	account.XblUserHash = uhs
+	return nil
+}
+
+// Exchanges an RPS ticket for an xbox live user token and the user hash (uhs) that goes with it. The ticket is a live.com access token
+// with the service::user.auth.xboxlive.com::MBI_SSL scope, access tokens of an Azure app must be prefixed with "d=".
+// client is DefaultClient's if nil, as for the other steps of the chain.
+func ExchangeRpsForXbl(client *http.Client, rpsTicket string) (token string, uhs string, err error) {
+	if client == nil {
+		client = DefaultClient.HTTP
+	}
 	data := xBLSignInBody{
 		Properties: struct {
 			Authmethod string "json:\"AuthMethod\""
@@ -188,7 +320,7 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 		}{
 			Authmethod: "RPS",
 			Sitename:   "user.auth.xboxlive.com",
-			Rpsticket:  loginData["access_token"],
+			Rpsticket:  rpsTicket,
 		},
 		Relyingparty: "http://auth.xboxlive.com",
 		Tokentype:    "JWT",
@@ -196,40 +328,55 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 
 	encodedBody, err := json.Marshal(data)
 	if err != nil {
-		return err
+		return "", "", err
 	}
-	req, err = http.NewRequest("POST", "https://user.auth.xboxlive.com/user/authenticate", bytes.NewReader(encodedBody))
+	req, err := http.NewRequest("POST", "https://user.auth.xboxlive.com/user/authenticate", bytes.NewReader(encodedBody))
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("x-xbl-contract-version", "1")
 
-	resp, err = client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	defer resp.Body.Close()
 
 	respBodyBytes, err := ioutil.ReadAll(resp.Body)
 	if resp.StatusCode == 400 {
-		return errors.New("invalid Rpsticket field probably")
+		return "", "", errors.New("invalid Rpsticket field probably")
 	}
 
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	var respBody XBLSignInResp
 
 	json.Unmarshal(respBodyBytes, &respBody)
 
-	uhs := respBody.Displayclaims.Xui[0].Uhs
-	XBLToken := respBody.Token
+	if len(respBody.Displayclaims.Xui) == 0 {
+		return "", "", fmt.Errorf("got status %v and no user hash when signing in to xbox live", resp.Status)
+	}
+
+	return respBody.Token, respBody.Displayclaims.Xui[0].Uhs, nil
+}
+
+// exchanges an xbox live user token for an XSTS token for minecraftservices
+func ExchangeXblForXsts(client *http.Client, xblToken string) (string, error) {
+	xsts, err := exchangeXsts(client, xblToken, "rp://api.minecraftservices.com/")
+	return xsts.Token, err
+}
 
+// exchanges an xbox live user token for an XSTS token for relyingParty, the response's claims depend on the relying party
+func exchangeXsts(client *http.Client, xblToken string, relyingParty string) (xSTSAuthorizeResponse, error) {
+	if client == nil {
+		client = DefaultClient.HTTP
+	}
 	xstsBody := xSTSPostBody{
 		Properties: struct {
 			Sandboxid  string   "json:\"SandboxId\""
@@ -237,32 +384,34 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 		}{
 			Sandboxid: "RETAIL",
 			Usertokens: []string{
-				XBLToken,
+				xblToken,
 			},
 		},
-		Relyingparty: "rp://api.minecraftservices.com/",
+		Relyingparty: relyingParty,
 		Tokentype:    "JWT",
 	}
 
 	encodedXstsBody, err := json.Marshal(xstsBody)
 	if err != nil {
-		return err
+		return xSTSAuthorizeResponse{}, err
 	}
-	req, err = http.NewRequest("POST", "https://xsts.auth.xboxlive.com/xsts/authorize", bytes.NewReader(encodedXstsBody))
+	req, err := http.NewRequest("POST", "https://xsts.auth.xboxlive.com/xsts/authorize", bytes.NewReader(encodedXstsBody))
 	if err != nil {
-		return err
+		return xSTSAuthorizeResponse{}, err
 	}
 
-	resp, err = client.Do(req)
+	resp, err := client.Do(req)
 
 	if err != nil {
-		return err
+		return xSTSAuthorizeResponse{}, err
 	}
 
-	respBodyBytes, err = ioutil.ReadAll(resp.Body)
+	defer resp.Body.Close()
+
+	respBodyBytes, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return err
+		return xSTSAuthorizeResponse{}, err
 	}
 
 	if resp.StatusCode == 401 {
@@ -271,24 +420,42 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 		switch authorizeXstsFail.Xerr {
 		case 2148916238:
 			{
-				return errors.New("microsoft account belongs to someone under 18! add to family for this to work")
+				return xSTSAuthorizeResponse{}, errors.New("microsoft account belongs to someone under 18! add to family for this to work")
 			}
 		case 2148916233:
 			{
-				return errors.New("you have no xbox account! Sign up for one to continue")
+				return xSTSAuthorizeResponse{}, errors.New("you have no xbox account! Sign up for one to continue")
 			}
 		default:
 			{
-				return fmt.Errorf("got error code %v when trying to authorize XSTS token", authorizeXstsFail.Xerr)
+				return xSTSAuthorizeResponse{}, fmt.Errorf("got error code %v when trying to authorize XSTS token", authorizeXstsFail.Xerr)
 			}
 		}
 	}
 
+	if resp.StatusCode >= 400 {
+		return xSTSAuthorizeResponse{}, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when authorizing XSTS token", resp.Status),
+		}
+	}
+
 	var xstsAuthorizeResp xSTSAuthorizeResponse
-	json.Unmarshal(respBodyBytes, &xstsAuthorizeResp)
+	if err := json.Unmarshal(respBodyBytes, &xstsAuthorizeResp); err != nil {
+		return xSTSAuthorizeResponse{}, err
+	}
+	if xstsAuthorizeResp.Token == "" {
+		return xSTSAuthorizeResponse{}, fmt.Errorf("got status %v and no XSTS token when authorizing with xbox live", resp.Status)
+	}
 
-	xstsToken := xstsAuthorizeResp.Token
+	return xstsAuthorizeResp, nil
+}
 
+// logs in to minecraftservices with an XSTS token and its user hash, returning the minecraft bearer
+func LoginWithXbox(client *http.Client, uhs, xstsToken string) (string, error) {
+	if client == nil {
+		client = DefaultClient.HTTP
+	}
 	mojangBearerBody := msGetMojangbearerBody{
 		Identitytoken:       "XBL3.0 x=" + uhs + ";" + xstsToken,
 		Ensurelegacyenabled: true,
@@ -297,33 +464,40 @@ func (account *MCaccount) MicrosoftAuthenticate() error {
 	mojangBearerBodyEncoded, err := json.Marshal(mojangBearerBody)
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	req, err = http.NewRequest("POST", "https://api.minecraftservices.com/authentication/login_with_xbox", bytes.NewReader(mojangBearerBodyEncoded))
-
-	req.Header.Set("Content-Type", "application/json")
+	req, err := http.NewRequest("POST", "https://api.minecraftservices.com/authentication/login_with_xbox", bytes.NewReader(mojangBearerBodyEncoded))
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	resp, err = client.Do(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	defer resp.Body.Close()
+
 	mcBearerResponseBytes, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if resp.StatusCode >= 400 {
+		return "", &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when logging in with xbox", resp.Status),
+		}
 	}
 
 	var mcBearerResp msGetMojangBearerResponse
 
 	json.Unmarshal(mcBearerResponseBytes, &mcBearerResp)
 
-	account.Bearer = mcBearerResp.AccessToken
-
-	return nil
+	return mcBearerResp.AccessToken, nil
 }
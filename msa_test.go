@@ -1,18 +1,59 @@
 package mcgo
 
 import (
-	"os"
+	"crypto/tls"
+	"net/http"
+	"net/url"
 	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
 )
 
 func TestMsa(t *testing.T) {
-	email := os.Getenv("MS_EMAIL")
-	pass := os.Getenv("MS_PASSWORD")
-	acc := MCaccount{
-		Email:    email,
-		Password: pass,
-	}
+	acc := liveAccount(t, Ms)
 	if err := acc.MicrosoftAuthenticate(); err != nil || acc.Bearer == "" {
 		t.Fatal(err)
 	}
 }
+
+func TestMicrosoftAuthenticate(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "live@example.com", Password: "hunter2", Name: "Live", OwnsGame: true})
+	acc := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Ms, Client: &Client{HTTP: srv.HTTPClient()}}
+
+	if err := acc.MicrosoftAuthenticate(); err != nil || acc.Bearer != fake.Bearer || acc.Username != "Live" {
+		t.Fatalf("err: %v | bearer: %v | expected the account's bearer", err, acc.Bearer)
+	}
+	if acc.MsRefreshToken != "refresh:live@example.com" || acc.MsClientID != msPasswordClientID {
+		t.Fatalf("refresh token: %q | client id: %q | expected both to be kept for RefreshMsToken", acc.MsRefreshToken, acc.MsClientID)
+	}
+	if err := acc.RefreshMsToken(); err != nil {
+		t.Fatalf("err: %v | expected the kept refresh token to work", err)
+	}
+
+	acc.Password = "wrong"
+	if err := acc.MicrosoftAuthenticate(); err == nil {
+		t.Fatal("expected a wrong password to fail")
+	}
+
+	// logins in the background apply the client id with the refresh token
+	background := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Ms, Client: &Client{HTTP: srv.HTTPClient()}}
+	if err := background.reauthenticate(); err != nil || background.MsRefreshToken == "" || background.MsClientID != msPasswordClientID {
+		t.Fatalf("err: %v | refresh token: %q | client id: %q | expected both to be applied", err, background.MsRefreshToken, background.MsClientID)
+	}
+}
+
+func TestMsTransport(t *testing.T) {
+	proxied := &http.Transport{Proxy: http.ProxyURL(&url.URL{Scheme: "http", Host: "127.0.0.1:8080"})}
+	acc := &MCaccount{Client: &Client{HTTP: &http.Client{Transport: proxied}}}
+
+	transport, ok := acc.msTransport().(*http.Transport)
+	if !ok || transport == proxied || transport.Proxy == nil {
+		t.Fatalf("transport: %+v | expected a copy of the account's transport keeping its proxy", transport)
+	}
+	if config := transport.TLSClientConfig; config.InsecureSkipVerify || config.Renegotiation != tls.RenegotiateOnceAsClient {
+		t.Fatalf("tls config: %+v | expected renegotiation with the certificates verified", config)
+	}
+}
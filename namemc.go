@@ -1,65 +1,106 @@
 package mcgo
 
 import (
-	"strings"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"time"
+)
+
+// returned by StartNamemcClaim when mcgo is built with the nonamemc tag
+var ErrNamemcDisabled = errors.New("namemc claims are not available, mcgo was built with the nonamemc tag")
+
+// A NameMC claim started for an account. Opening URL while logged in to NameMC (in any browser, on any machine) completes it.
+type NamemcClaim struct {
+	URL      string
+	Key      string
+	Attempts []NamemcAttempt // every try StartNamemcClaim made, the last one got the url (or the error returned)
+}
 
-	"github.com/Tnze/go-mc/bot"
-	"github.com/Tnze/go-mc/bot/basic"
-	"github.com/Tnze/go-mc/chat"
-	pk "github.com/Tnze/go-mc/net/packet"
-	"github.com/google/uuid"
+// One try to get a claim url.
+type NamemcAttempt struct {
+	Time time.Time
+	Err  error
+}
+
+// Right after an auth or rename the server often refuses the join until the session propagated, so StartNamemcClaim retries.
+var (
+	NamemcAttempts = 4
+	NamemcBackoff  = time.Second * 2 // wait after the first failed attempt, doubling after each one
 )
 
-var client *bot.Client
+// replaced by tests
+var namemcAttempt = (*MCaccount).startNamemcClaim
 
-func (account *MCaccount) ClaimNamemc() (string, error) {
-	client = bot.NewClient()
+// the server disconnected the bot, while joining or before it got the claim url
+type namemcKickError struct {
+	err error
+}
 
-	client.Auth.Name = account.Username
-	client.Auth.UUID = account.UUID
-	client.Auth.AsTk = account.Bearer
+func (e *namemcKickError) Error() string {
+	return e.err.Error()
+}
 
-	claimUrlChan := make(chan string)
+func (e *namemcKickError) Unwrap() error {
+	return e.err
+}
 
-	basic.EventsListener{
-		GameStart: func() error {
-			go func() {
-				// sleep and send /namemc cmd
-				time.Sleep(time.Millisecond * 500)
-				client.Conn.WritePacket(pk.Marshal(
-					0x03,
-					pk.String("/namemc"),
-				))
-			}()
-			return nil
-		},
-		ChatMsg: func(c chat.Message, pos byte, uuid uuid.UUID) error {
-			cStr := c.ClearString()
-			if strings.Contains(cStr, "https://namemc.com/claim?key=") {
-				go func() {
-					claimUrlChan <- cStr
-				}()
-			}
-			return nil
-		},
-	}.Attach(client)
+// whether a failed attempt can succeed when retried: network trouble, rate limits, server errors, and kicks (usually a session that
+// hasn't propagated yet). Others, like a bearer the session server refuses, fail the same way every time.
+func retryableNamemcErr(err error) bool {
+	switch Categorize(err) {
+	case CategoryNetwork, CategoryRateLimit:
+		return true
+	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.StatusCode >= 500
+	}
+	var kicked *namemcKickError
+	return errors.As(err, &kicked) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
 
-	err := client.JoinServer("blockmania.com")
+// Gets a claim url for the account, without completing the claim. Attempts failing for a reason that can pass (see retryableNamemcErr)
+// are retried up to NamemcAttempts in total, waiting NamemcBackoff (doubling) in between. The attempts are recorded in the returned claim, also when all of them failed.
+func (account *MCaccount) StartNamemcClaim() (NamemcClaim, error) {
+	var attempts []NamemcAttempt
+	backoff := NamemcBackoff
+	for {
+		start := account.now()
+		claim, err := namemcAttempt(account)
+		attempts = append(attempts, NamemcAttempt{Time: start, Err: err})
+		if err == nil || !retryableNamemcErr(err) || len(attempts) >= NamemcAttempts {
+			claim.Attempts = attempts
+			return claim, err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// Completes a claim by requesting its url with client, which must carry the cookies of a logged in NameMC session.
+func CompleteNamemcClaim(claim NamemcClaim, client *http.Client) error {
+	resp, err := client.Get(claim.URL)
 	if err != nil {
-		return "", err
+		return err
 	}
+	defer resp.Body.Close()
 
-	go func() error {
-		//JoinGame
-		err = client.HandleGame()
-		if err == nil {
-			return err
+	if resp.StatusCode >= 400 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when completing namemc claim", resp.Status),
 		}
-		return nil
-	}()
-
-	claimUrl := <-claimUrlChan
-	return claimUrl, nil
+	}
+	return nil
+}
 
+// Starts a NameMC claim and returns its url, open it while logged in to NameMC to complete the claim.
+func (account *MCaccount) ClaimNamemc() (string, error) {
+	claim, err := account.StartNamemcClaim()
+	if err != nil {
+		return "", err
+	}
+	return claim.URL, nil
 }
@@ -0,0 +1,92 @@
+//go:build !nonamemc
+// +build !nonamemc
+
+package mcgo
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/Tnze/go-mc/bot"
+	"github.com/Tnze/go-mc/bot/basic"
+	"github.com/Tnze/go-mc/chat"
+	pk "github.com/Tnze/go-mc/net/packet"
+	"github.com/google/uuid"
+)
+
+var claimUrlRegex = regexp.MustCompile(`https://namemc\.com/claim\?key=[\w-]+`)
+
+// marks the server kicking the bot, so the attempt is retried
+func kickError(err error) error {
+	var kicked bot.DisconnectErr
+	if errors.As(err, &kicked) {
+		return &namemcKickError{err: err}
+	}
+	return err
+}
+
+// Joins blockmania.com with the account and runs /namemc to get a claim url, one attempt of StartNamemcClaim.
+func (account *MCaccount) startNamemcClaim() (NamemcClaim, error) {
+	client := bot.NewClient()
+
+	auth := account.snapshot()
+	client.Auth.Name = auth.Username
+	client.Auth.UUID = auth.UUID
+	client.Auth.AsTk = auth.Bearer
+
+	claimUrlChan := make(chan string, 1)
+
+	basic.EventsListener{
+		GameStart: func() error {
+			go func() {
+				// sleep and send /namemc cmd
+				time.Sleep(time.Millisecond * 500)
+				client.Conn.WritePacket(pk.Marshal(
+					0x03,
+					pk.String("/namemc"),
+				))
+			}()
+			return nil
+		},
+		ChatMsg: func(c chat.Message, pos byte, uuid uuid.UUID) error {
+			if claimUrl := claimUrlRegex.FindString(c.ClearString()); claimUrl != "" {
+				select {
+				case claimUrlChan <- claimUrl:
+				default:
+				}
+			}
+			return nil
+		},
+	}.Attach(client)
+
+	err := client.JoinServer("blockmania.com")
+	if err != nil {
+		return NamemcClaim{}, kickError(err)
+	}
+	defer client.Close()
+
+	gameErr := make(chan error, 1)
+	go func() {
+		//JoinGame
+		gameErr <- client.HandleGame()
+	}()
+
+	var claimUrl string
+	select {
+	case claimUrl = <-claimUrlChan:
+	case err := <-gameErr:
+		if err == nil {
+			err = errors.New("disconnected before receiving a namemc claim url")
+		}
+		return NamemcClaim{}, &namemcKickError{err: err}
+	}
+
+	parsed, err := url.Parse(claimUrl)
+	if err != nil {
+		return NamemcClaim{}, err
+	}
+
+	return NamemcClaim{URL: claimUrl, Key: parsed.Query().Get("key")}, nil
+}
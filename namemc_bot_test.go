@@ -0,0 +1,13 @@
+//go:build !nonamemc
+// +build !nonamemc
+
+package mcgo
+
+import "testing"
+
+func TestClaimUrlRegex(t *testing.T) {
+	msg := "[NameMC] Click here to claim: https://namemc.com/claim?key=ab12-CD34 (expires in 5 minutes)"
+	if found := claimUrlRegex.FindString(msg); found != "https://namemc.com/claim?key=ab12-CD34" {
+		t.Fatalf("found: %v | expected claim url", found)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build nonamemc
+// +build nonamemc
+
+package mcgo
+
+// StartNamemcClaim needs go-mc to join a server, which the nonamemc tag leaves out.
+func (account *MCaccount) startNamemcClaim() (NamemcClaim, error) {
+	return NamemcClaim{}, ErrNamemcDisabled
+}
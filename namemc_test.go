@@ -1,18 +1,16 @@
 package mcgo
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"net"
 	"testing"
+	"time"
 )
 
 func TestClaimNamemc(t *testing.T) {
-	email := os.Getenv("MJ_EMAIL")
-	pass := os.Getenv("MJ_PASS")
-	acc := MCaccount{
-		Email:    email,
-		Password: pass,
-	}
+	acc := liveAccount(t, Mj)
 	acc.MojangAuthenticate()
 	acc.LoadAccountInfo()
 	url, err := acc.ClaimNamemc()
@@ -21,3 +19,55 @@ func TestClaimNamemc(t *testing.T) {
 	}
 	fmt.Println(url)
 }
+
+func TestStartNamemcClaimRetries(t *testing.T) {
+	defer func(attempt func(*MCaccount) (NamemcClaim, error), backoff time.Duration) {
+		namemcAttempt, NamemcBackoff = attempt, backoff
+	}(namemcAttempt, NamemcBackoff)
+	NamemcBackoff = time.Millisecond
+
+	tries := 0
+	namemcAttempt = func(*MCaccount) (NamemcClaim, error) {
+		tries++
+		if tries < 3 {
+			return NamemcClaim{}, &namemcKickError{err: errors.New("kicked: session not found")}
+		}
+		return NamemcClaim{URL: "https://namemc.com/claim?key=abc", Key: "abc"}, nil
+	}
+
+	claim, err := (&MCaccount{}).StartNamemcClaim()
+	if err != nil || claim.Key != "abc" || len(claim.Attempts) != 3 || claim.Attempts[0].Err == nil || claim.Attempts[2].Err != nil {
+		t.Fatalf("err: %v | claim: %+v | expected success on the third attempt", err, claim)
+	}
+
+	tries = -10
+	claim, err = (&MCaccount{}).StartNamemcClaim()
+	if err == nil || len(claim.Attempts) != NamemcAttempts {
+		t.Fatalf("err: %v | attempts: %v | expected to give up after %v attempts", err, len(claim.Attempts), NamemcAttempts)
+	}
+
+	namemcAttempt = func(*MCaccount) (NamemcClaim, error) {
+		return NamemcClaim{}, errors.New("auth fail: invalid session")
+	}
+	if claim, err = (&MCaccount{}).StartNamemcClaim(); err == nil || len(claim.Attempts) != 1 {
+		t.Fatalf("err: %v | attempts: %v | expected errors that won't pass not to be retried", err, len(claim.Attempts))
+	}
+}
+
+func TestRetryableNamemcErr(t *testing.T) {
+	for _, c := range []struct {
+		err  error
+		want bool
+	}{
+		{&RequestError{StatusCode: 503, Err: errors.New("unavailable")}, true},
+		{&RequestError{StatusCode: 429, Err: errors.New("slow down")}, true},
+		{&RequestError{StatusCode: 403, Err: errors.New("forbidden")}, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{ErrNamemcDisabled, false},
+	} {
+		if got := retryableNamemcErr(c.err); got != c.want {
+			t.Fatalf("err: %v | retryable: %v | expected %v", c.err, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package mcgo
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rules a name has to follow for Mojang to accept it, checked before creates and renames are sent.
+type NamePolicy struct {
+	MinLength int
+	MaxLength int
+	Charset   string   // every character a name may contain
+	Blocked   []string // words a name is refused for containing, compared ignoring case, underscores and leet substitutions
+}
+
+// Policy used by CheckName, ChangeName and Burst. Replace it when Mojang changes its rules.
+var DefaultNamePolicy = NamePolicy{
+	MinLength: 3,
+	MaxLength: 16,
+	Charset:   "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_",
+	Blocked:   []string{"mojang", "minecraft"},
+}
+
+// Returned when a name breaks the name policy, without anything being sent.
+type NamePolicyError struct {
+	Name   string
+	Reason string
+}
+
+func (e *NamePolicyError) Error() string {
+	return fmt.Sprintf("name %q is not allowed: %v", e.Name, e.Reason)
+}
+
+// lowercases name and undoes leet substitutions and underscores, so blocked words can't be dodged with them
+func normalizeName(name string) string {
+	undo := map[rune]rune{}
+	for letter, digit := range leetReplacements {
+		undo[digit] = letter
+	}
+
+	var b strings.Builder
+	for _, c := range strings.ToLower(name) {
+		if c == '_' {
+			continue
+		}
+		if letter, ok := undo[c]; ok {
+			c = letter
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// returns a NamePolicyError if name breaks the policy
+func (p NamePolicy) Check(name string) error {
+	length := utf8.RuneCountInString(name)
+	if length < p.MinLength || length > p.MaxLength {
+		return &NamePolicyError{Name: name, Reason: fmt.Sprintf("must be %v to %v characters long", p.MinLength, p.MaxLength)}
+	}
+	for _, c := range name {
+		if !strings.ContainsRune(p.Charset, c) {
+			return &NamePolicyError{Name: name, Reason: fmt.Sprintf("contains %q", c)}
+		}
+	}
+
+	normalized := normalizeName(name)
+	for _, word := range p.Blocked {
+		if strings.Contains(normalized, normalizeName(word)) {
+			return &NamePolicyError{Name: name, Reason: fmt.Sprintf("contains blocked word %q", word)}
+		}
+	}
+	return nil
+}
+
+// checks name against DefaultNamePolicy
+func CheckName(name string) error {
+	return DefaultNamePolicy.Check(name)
+}
@@ -0,0 +1,36 @@
+package mcgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNamePolicy(t *testing.T) {
+	for name, ok := range map[string]bool{
+		"Notch":             true,
+		"a_b":               true,
+		"ab":                false,
+		"seventeen_letters": false,
+		"has space":         false,
+		"dash-name":         false,
+		"MojangFan":         false,
+		"m0j4ng_":           false,
+		"Mine_Cr4ft":        false,
+	} {
+		if err := CheckName(name); (err == nil) != ok {
+			t.Fatalf("err: %v | name: %v | expected allowed: %v", err, name, ok)
+		}
+	}
+
+	custom := NamePolicy{MinLength: 1, MaxLength: 3, Charset: "ab"}
+	if custom.Check("ab") != nil || custom.Check("abc") == nil {
+		t.Fatal("expected the custom policy to be used")
+	}
+
+	_, err := (&MCaccount{}).ChangeName("no way", time.Now(), false)
+	var policyErr *NamePolicyError
+	if !errors.As(err, &policyErr) || policyErr.Name != "no way" {
+		t.Fatalf("err: %v | expected a NamePolicyError before anything is sent", err)
+	}
+}
@@ -0,0 +1,84 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+)
+
+type NameStatus string
+
+const (
+	NameTaken     NameStatus = "TAKEN"     // a profile currently has the name
+	NameBlocked   NameStatus = "BLOCKED"   // nobody has the name, but it can't be claimed yet (recently released)
+	NameAvailable NameStatus = "AVAILABLE" // the name can be claimed now
+	NameInvalid   NameStatus = "INVALID"   // the name is not allowed at all (length, characters, filtered words)
+)
+
+type nameAvailableResponse struct {
+	Status string `json:"status"`
+}
+
+// returns whether a profile currently owns the name, using the public (unauthenticated) lookup
+func (account *MCaccount) nameOwned(username string) (bool, error) {
+	_, owned, err := account.publicLookup(username)
+	return owned, err
+}
+
+// returns the profile owning the name, owned is false if nobody does
+func (account *MCaccount) publicLookup(username string) (profile bulkProfileResp, owned bool, err error) {
+	resp, err := account.client().Get("https://api.mojang.com/users/profiles/minecraft/" + url.PathEscape(username))
+	if err != nil {
+		return profile, false, err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		respBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return profile, false, err
+		}
+		return profile, true, json.Unmarshal(respBytes, &profile)
+	case 204, 404:
+		return profile, false, nil
+	case 429:
+		return profile, false, &RequestError{StatusCode: resp.StatusCode, Err: errors.New("mojang API ratelimit reached")}
+	}
+	return profile, false, &RequestError{
+		StatusCode: resp.StatusCode,
+		Err:        fmt.Errorf("got status %v on public lookup of %v", resp.Status, username),
+	}
+}
+
+// Checks if a name is taken, blocked (free but not claimable yet) or available, by combining the public lookup with the account's availability endpoint.
+// The availability endpoint alone reports blocked and taken names the same way, so a name is only BLOCKED if nobody owns it publicly.
+func (account *MCaccount) NameStatus(username string) (NameStatus, error) {
+	owned, err := account.nameOwned(username)
+	if err != nil {
+		return "", err
+	}
+
+	if owned {
+		return NameTaken, nil
+	}
+
+	var available nameAvailableResponse
+	err = account.getJSON(fmt.Sprintf("https://api.minecraftservices.com/minecraft/profile/name/%v/available", url.PathEscape(username)), &available)
+	if err != nil {
+		return "", err
+	}
+
+	switch available.Status {
+	case "AVAILABLE":
+		return NameAvailable, nil
+	case "DUPLICATE":
+		return NameBlocked, nil
+	case "NOT_ALLOWED":
+		return NameInvalid, nil
+	}
+	return "", fmt.Errorf("unknown name availability status %v", available.Status)
+}
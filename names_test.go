@@ -0,0 +1,29 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestNameStatus(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	srv.AddAccount(mcgotest.Account{Name: "Taken", OwnsGame: true})
+	srv.BlockName("Released")
+	acc := newFakeAccount(srv, mcgotest.Account{Name: "Checker", OwnsGame: true})
+
+	cases := map[string]NameStatus{
+		"taken":     NameTaken,
+		"Released":  NameBlocked,
+		"Free_Name": NameAvailable,
+		"a":         NameInvalid,
+	}
+	for name, expected := range cases {
+		status, err := acc.NameStatus(name)
+		if err != nil || status != expected {
+			t.Fatalf("name: %v | err: %v | status: %v | expected status: %v", name, err, status, expected)
+		}
+	}
+}
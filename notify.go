@@ -0,0 +1,255 @@
+package mcgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	EventSnipeSucceeded EventType = "snipe_succeeded"
+	EventSnipeFailed    EventType = "snipe_failed"
+)
+
+func (p Priority) String() string {
+	if p == PriorityHigh {
+		return "high"
+	}
+	return "normal"
+}
+
+// Event for the result of a burst, high priority if it got the name.
+func (account *MCaccount) BurstEvent(result BurstResult) Event {
+	if result.Succeeded() {
+		return account.newEvent(EventSnipeSucceeded, PriorityHigh,
+			fmt.Sprintf("sniped %v with request %v of %v", result.Username, result.Position()+1, len(result.Results)))
+	}
+
+	message := fmt.Sprintf("failed to snipe %v with %v requests", result.Username, len(result.Results))
+	for _, err := range result.Errors {
+		if err != nil {
+			message += fmt.Sprintf(": %v", err)
+			break
+		}
+	}
+	return account.newEvent(EventSnipeFailed, PriorityNormal, message)
+}
+
+// Something events are sent to, like a discord channel.
+type Notifier interface {
+	Notify(event Event) error
+}
+
+// client of notifiers without one, the timeout keeps a hung endpoint from stalling whatever sends the event
+var notificationClient = &http.Client{Timeout: time.Second * 10}
+
+// posts v as json to endpoint, statuses other than 2xx are errors
+func postNotification(client *http.Client, endpoint string, v interface{}) error {
+	if client == nil {
+		client = notificationClient
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// telegram tokens and discord webhook ids are in the path, only the host is kept for OnError
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactedEndpoint(endpoint)
+		}
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when sending notification", resp.Status),
+		}
+	}
+	return nil
+}
+
+// endpoint without its path and query, which hold the secrets of notifiers
+func redactedEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return redactedSecret
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+}
+
+// one line of text for chat notifiers
+func notificationText(event Event) string {
+	text := fmt.Sprintf("[%v] %v", event.Type, event.Message)
+	if event.Account != "" {
+		text += fmt.Sprintf(" (%v)", maskEmail(event.Account))
+	}
+	return text
+}
+
+// Posts events to a discord webhook.
+type DiscordNotifier struct {
+	WebhookURL string
+	Client     *http.Client // a client with a 10 second timeout if nil
+}
+
+func (n DiscordNotifier) Notify(event Event) error {
+	return postNotification(n.Client, n.WebhookURL, map[string]string{"content": notificationText(event)})
+}
+
+// Sends events to a telegram chat with a bot.
+type TelegramNotifier struct {
+	Token  string
+	ChatID string
+	APIURL string       // https://api.telegram.org if empty
+	Client *http.Client // a client with a 10 second timeout if nil
+}
+
+func (n TelegramNotifier) Notify(event Event) error {
+	apiURL := n.APIURL
+	if apiURL == "" {
+		apiURL = "https://api.telegram.org"
+	}
+	endpoint := fmt.Sprintf("%v/bot%v/sendMessage", apiURL, url.PathEscape(n.Token))
+	return postNotification(n.Client, endpoint, map[string]string{"chat_id": n.ChatID, "text": notificationText(event)})
+}
+
+type webhookEvent struct {
+	Type     EventType `json:"type"`
+	Priority string    `json:"priority"`
+	Account  string    `json:"account"`
+	Message  string    `json:"message"`
+	Time     time.Time `json:"time"`
+}
+
+// Posts every event as a json object to URL, for anything that isn't discord or telegram.
+// The account is masked like in chat notifications unless RawAccount is set.
+type WebhookNotifier struct {
+	URL        string
+	Client     *http.Client // a client with a 10 second timeout if nil
+	RawAccount bool         // send the full email of the account, for endpoints that are trusted with it
+}
+
+func (n WebhookNotifier) Notify(event Event) error {
+	account := maskEmail(event.Account)
+	if n.RawAccount {
+		account = event.Account
+	}
+	return postNotification(n.Client, n.URL, webhookEvent{
+		Type:     event.Type,
+		Priority: event.Priority.String(),
+		Account:  account,
+		Message:  event.Message,
+		Time:     event.Time,
+	})
+}
+
+// Which events a notifier gets, the zero value lets everything through.
+type EventFilter struct {
+	Types       []EventType // only these types, any type if empty
+	MinPriority Priority
+}
+
+func (f EventFilter) Match(event Event) bool {
+	if event.Priority < f.MinPriority {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+type NotifierRoute struct {
+	Notifier Notifier
+	Filter   EventFilter
+}
+
+// Sends each event to every notifier whose filter matches it, in order. Notifiers that fail are reported to OnError
+// and don't keep the event from the others.
+type Broadcaster struct {
+	Routes  []NotifierRoute
+	OnError func(notifier Notifier, event Event, err error)
+}
+
+func (b *Broadcaster) Add(notifier Notifier, filter EventFilter) {
+	b.Routes = append(b.Routes, NotifierRoute{Notifier: notifier, Filter: filter})
+}
+
+// Handle is an EventHandler, so a broadcaster can be passed to Monitor, KeepAlive and EnableAutoRefresh as b.Handle.
+func (b *Broadcaster) Handle(event Event) {
+	for _, route := range b.Routes {
+		if !route.Filter.Match(event) {
+			continue
+		}
+		if err := route.Notifier.Notify(event); err != nil && b.OnError != nil {
+			b.OnError(route.Notifier, event, err)
+		}
+	}
+}
+
+// a notifier as written in a notifiers file
+type notifierJSON struct {
+	Kind        string      `json:"kind"`
+	URL         string      `json:"url"`
+	Token       string      `json:"token"`
+	ChatID      string      `json:"chatId"`
+	Events      []EventType `json:"events"`
+	MinPriority string      `json:"minPriority"`
+	RawAccount  bool        `json:"rawAccount"`
+}
+
+// Reads a broadcaster from a json array of notifiers, kind is discord, telegram or webhook. Notifiers without events get every type,
+// webhooks with "rawAccount": true get full emails, e.g.
+//
+//	[{"kind": "discord", "url": "https://discord.com/api/webhooks/...", "events": ["snipe_succeeded"]},
+//	 {"kind": "telegram", "token": "123:abc", "chatId": "42", "events": ["snipe_succeeded", "snipe_failed"]},
+//	 {"kind": "webhook", "url": "https://example.com/mcgo", "minPriority": "high"}]
+func LoadNotifiers(r io.Reader, client *http.Client) (*Broadcaster, error) {
+	var raw []notifierJSON
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	b := &Broadcaster{}
+	for i, n := range raw {
+		var notifier Notifier
+		switch n.Kind {
+		case "discord":
+			notifier = DiscordNotifier{WebhookURL: n.URL, Client: client}
+		case "telegram":
+			notifier = TelegramNotifier{Token: n.Token, ChatID: n.ChatID, Client: client}
+		case "webhook":
+			notifier = WebhookNotifier{URL: n.URL, Client: client, RawAccount: n.RawAccount}
+		default:
+			return nil, fmt.Errorf("notifier %v: unknown kind %q", i, n.Kind)
+		}
+
+		filter := EventFilter{Types: n.Events}
+		switch n.MinPriority {
+		case "", "normal":
+		case "high":
+			filter.MinPriority = PriorityHigh
+		default:
+			return nil, fmt.Errorf("notifier %v: unknown priority %q", i, n.MinPriority)
+		}
+		b.Add(notifier, filter)
+	}
+	return b, nil
+}
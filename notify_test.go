@@ -0,0 +1,156 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingNotifier struct {
+	events []Event
+	err    error
+}
+
+func (n *recordingNotifier) Notify(event Event) error {
+	n.events = append(n.events, event)
+	return n.err
+}
+
+func TestBroadcasterFilters(t *testing.T) {
+	account := &MCaccount{Email: "sniper@example.com"}
+	won := account.BurstEvent(BurstResult{Username: "Target", Results: []NameChangeReturn{{}, {ChangedName: true}}})
+	lost := account.BurstEvent(BurstResult{Username: "Target", Results: []NameChangeReturn{{}}, Errors: []error{errors.New("too slow")}})
+	refreshed := account.newEvent(EventTokenRefreshed, PriorityNormal, "refreshed")
+
+	if won.Type != EventSnipeSucceeded || won.Priority != PriorityHigh || !strings.Contains(won.Message, "request 2 of 2") {
+		t.Fatalf("event: %+v | expected a high priority success naming the request", won)
+	}
+	if lost.Type != EventSnipeFailed || !strings.Contains(lost.Message, "too slow") {
+		t.Fatalf("event: %+v | expected a failure with the first error", lost)
+	}
+
+	results := &recordingNotifier{}
+	failures := &recordingNotifier{err: errors.New("down")}
+	everything := &recordingNotifier{}
+	var notifyErrs []error
+
+	b := &Broadcaster{OnError: func(_ Notifier, _ Event, err error) { notifyErrs = append(notifyErrs, err) }}
+	b.Add(results, EventFilter{MinPriority: PriorityHigh})
+	b.Add(failures, EventFilter{Types: []EventType{EventSnipeSucceeded, EventSnipeFailed}})
+	b.Add(everything, EventFilter{})
+
+	for _, event := range []Event{won, lost, refreshed} {
+		b.Handle(event)
+	}
+
+	if len(results.events) != 1 || len(failures.events) != 2 || len(everything.events) != 3 {
+		t.Fatalf("got %v, %v, %v events | expected 1, 2 and 3", len(results.events), len(failures.events), len(everything.events))
+	}
+	if len(notifyErrs) != 2 {
+		t.Fatalf("errors: %v | expected the failing notifier's errors to be reported", notifyErrs)
+	}
+}
+
+func TestLoadNotifiers(t *testing.T) {
+	var mu sync.Mutex
+	bodies := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var v map[string]interface{}
+		json.Unmarshal(body, &v)
+		mu.Lock()
+		bodies[r.URL.Path] = v
+		mu.Unlock()
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	b, err := LoadNotifiers(strings.NewReader(`[
+		{"kind": "discord", "url": "`+srv.URL+`/discord", "events": ["snipe_succeeded"]},
+		{"kind": "telegram", "token": "123:abc", "chatId": "42", "events": ["snipe_succeeded", "snipe_failed"]},
+		{"kind": "webhook", "url": "`+srv.URL+`/hook", "minPriority": "high"}
+	]`), srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Routes) != 3 {
+		t.Fatalf("routes: %v | expected 3", len(b.Routes))
+	}
+	telegram := b.Routes[1].Notifier.(TelegramNotifier)
+	telegram.APIURL = srv.URL
+	b.Routes[1].Notifier = telegram
+
+	account := &MCaccount{Email: "sniper@example.com"}
+	b.Handle(account.BurstEvent(BurstResult{Username: "Target", Results: []NameChangeReturn{{ChangedName: true}}}))
+
+	if content, _ := bodies["/discord"]["content"].(string); !strings.Contains(content, "sniped Target") {
+		t.Fatalf("discord: %v | expected the result as content", bodies["/discord"])
+	}
+	if bodies["/bot123:abc/sendMessage"]["chat_id"] != "42" {
+		t.Fatalf("bodies: %v | expected a telegram message to chat 42", bodies)
+	}
+	if bodies["/hook"]["type"] != string(EventSnipeSucceeded) || bodies["/hook"]["priority"] != "high" {
+		t.Fatalf("webhook: %v | expected the event as json", bodies["/hook"])
+	}
+	if bodies["/hook"]["account"] != "sn***@example.com" {
+		t.Fatalf("webhook: %v | expected the email to be masked", bodies["/hook"])
+	}
+
+	raw := WebhookNotifier{URL: srv.URL + "/raw", Client: srv.Client(), RawAccount: true}
+	if err := raw.Notify(account.newEvent(EventSnipeFailed, PriorityNormal, "failed")); err != nil {
+		t.Fatal(err)
+	}
+	if bodies["/raw"]["account"] != "sniper@example.com" {
+		t.Fatalf("webhook: %v | expected the full email with RawAccount", bodies["/raw"])
+	}
+
+	if _, err := LoadNotifiers(strings.NewReader(`[{"kind": "pager"}]`), nil); err == nil {
+		t.Fatal("expected an unknown kind to be an error")
+	}
+}
+
+func TestNotificationTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hang
+	}))
+	defer srv.Close()
+	defer close(hang)
+
+	if notificationClient.Timeout == 0 {
+		t.Fatal("expected notifiers without a client to time out")
+	}
+	defaultClient := notificationClient
+	notificationClient = &http.Client{Timeout: time.Millisecond * 50}
+	defer func() { notificationClient = defaultClient }()
+
+	start := time.Now()
+	if err := (WebhookNotifier{URL: srv.URL}).Notify(Event{Type: EventSnipeFailed}); err == nil {
+		t.Fatal("expected a hung webhook to be an error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("elapsed: %v | expected the notification to give up after the timeout", elapsed)
+	}
+}
+
+func TestNotificationErrorsHideSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	apiURL := srv.URL
+	srv.Close()
+
+	for _, n := range []Notifier{
+		TelegramNotifier{Token: "123:secret-token", ChatID: "42", APIURL: apiURL},
+		DiscordNotifier{WebhookURL: apiURL + "/api/webhooks/1/secret-token"},
+	} {
+		err := n.Notify(Event{Type: EventSnipeFailed})
+		if err == nil || strings.Contains(err.Error(), "secret-token") || !strings.Contains(err.Error(), apiURL) {
+			t.Fatalf("err: %v | expected a failed request naming only the host", err)
+		}
+	}
+}
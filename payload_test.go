@@ -0,0 +1,198 @@
+package mcgo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+// dials the fake server and keeps every byte written to the connections
+type recordingDialer struct {
+	srv *mcgotest.Server
+
+	mu      sync.Mutex
+	written bytes.Buffer
+}
+
+type recordingConn struct {
+	net.Conn
+	dialer *recordingDialer
+}
+
+func (d *recordingDialer) Dial(network, addr string) (net.Conn, error) {
+	conn, err := d.srv.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingConn{Conn: conn, dialer: d}, nil
+}
+
+func (c *recordingConn) Write(b []byte) (int, error) {
+	c.dialer.mu.Lock()
+	c.dialer.written.Write(b)
+	c.dialer.mu.Unlock()
+	return c.Conn.Write(b)
+}
+
+func TestNamePayloads(t *testing.T) {
+	create := "POST /minecraft/profile HTTP/1.1\r\n" +
+		"Host: api.minecraftservices.com\r\n" +
+		"Authorization: Bearer bearer-1\r\n" +
+		"Content-Type: application/json\r\n" +
+		"Content-Length: 25\r\n" +
+		"\r\n" +
+		`{"profileName": "Wanted"}`
+
+	cases := []struct {
+		flow    string
+		account mcgotest.Account
+		change  func(acc *MCaccount) (NameChangeReturn, error)
+		bytes   string
+		status  int
+	}{
+		{
+			flow:    "rename",
+			account: mcgotest.Account{Name: "Current", OwnsGame: true, NameChangeAllowed: true},
+			change: func(acc *MCaccount) (NameChangeReturn, error) {
+				return acc.ChangeName("Wanted", time.Now(), false)
+			},
+			bytes:  "PUT /minecraft/profile/name/Wanted HTTP/1.1\r\nHost: api.minecraftservices.com\r\nAuthorization: Bearer bearer-1\r\n\r\n",
+			status: 200,
+		},
+		{
+			flow:    "snipe create",
+			account: mcgotest.Account{OwnsGame: true},
+			change: func(acc *MCaccount) (NameChangeReturn, error) {
+				return acc.ChangeName("Wanted", time.Now(), true)
+			},
+			bytes:  create,
+			status: 200,
+		},
+		{
+			flow:    "prename create",
+			account: mcgotest.Account{OwnsGame: true},
+			change: func(acc *MCaccount) (NameChangeReturn, error) {
+				return acc.ChangeName1("Wanted", time.Now(), false)
+			},
+			bytes:  create,
+			status: 200,
+		},
+	}
+
+	for _, c := range cases {
+		srv := mcgotest.NewServer()
+		dialer := &recordingDialer{srv: srv}
+		acc := newFakeAccount(srv, c.account)
+		acc.Dialer = dialer
+
+		ret, err := c.change(acc)
+		srv.Close()
+
+		if err != nil || ret.StatusCode != c.status {
+			t.Fatalf("err: %v | status: %v | %v should get %v", err, ret.StatusCode, c.flow, c.status)
+		}
+		if written := dialer.written.String(); written != c.bytes {
+			t.Fatalf("%v wrote %q | expected %q", c.flow, written, c.bytes)
+		}
+	}
+}
+
+// a random name mixing valid names with the characters that break hand built payloads: quotes, backslashes, line breaks,
+// braces, slashes, percent signs and multi byte unicode
+type payloadName string
+
+func (payloadName) Generate(r *rand.Rand, size int) reflect.Value {
+	alphabet := []rune("abcXYZ019_ \"\\\r\n\t{}/%?#:éß漢字🙂\u0000")
+	name := make([]rune, r.Intn(size+1))
+	for i := range name {
+		name[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return reflect.ValueOf(payloadName(name))
+}
+
+func readPayload(t *testing.T, payload string) (*http.Request, []byte) {
+	req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(payload)))
+	if err != nil {
+		t.Logf("err: %v | payload %q is not a valid request", err, payload)
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Logf("err: %v | payload %q has a short body", err, payload)
+		return nil, nil
+	}
+	return req, body
+}
+
+func TestCreatePayloadProperties(t *testing.T) {
+	err := quick.Check(func(name payloadName) bool {
+		payload := createPayload(string(name), "bearer")
+		req, body := readPayload(t, payload)
+		if req == nil {
+			return false
+		}
+		// Content-Length counts bytes, the whole payload is the request and its body
+		if req.ContentLength != int64(len(body)) || !strings.HasSuffix(payload, "\r\n\r\n"+string(body)) {
+			t.Logf("content length %v for %v body bytes in %q", req.ContentLength, len(body), payload)
+			return false
+		}
+		var decoded struct {
+			ProfileName *string `json:"profileName"`
+		}
+		if err := json.Unmarshal(body, &decoded); err != nil || decoded.ProfileName == nil || *decoded.ProfileName != string(name) {
+			t.Logf("err: %v | body %q doesn't hold the name %q", err, body, name)
+			return false
+		}
+		return req.Header.Get("Authorization") == "Bearer bearer"
+	}, &quick.Config{MaxCount: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenamePayloadProperties(t *testing.T) {
+	err := quick.Check(func(name payloadName) bool {
+		req, body := readPayload(t, renamePayload(string(name), "bearer"))
+		if req == nil {
+			return false
+		}
+		if req.Method != "PUT" || len(body) != 0 || req.URL.Path != "/minecraft/profile/name/"+string(name) || req.URL.RawQuery != "" {
+			t.Logf("method %v, path %q, query %q | expected a PUT of %q", req.Method, req.URL.Path, req.URL.RawQuery, name)
+			return false
+		}
+		return req.Header.Get("Authorization") == "Bearer bearer"
+	}, &quick.Config{MaxCount: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuthenticateBodyProperties(t *testing.T) {
+	err := quick.Check(func(email, password payloadName) bool {
+		data, err := json.Marshal(authenticateBody(string(email), string(password), "token"))
+		if err != nil {
+			return false
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Logf("err: %v | body %q is not valid json", err, data)
+			return false
+		}
+		return decoded["username"] == string(email) && decoded["password"] == string(password) && decoded["requestUser"] == true
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,81 @@
+package mcgo
+
+import (
+	"sync"
+	"time"
+)
+
+// Options of AuthenticateAll.
+type PoolOpts struct {
+	Workers int // accounts authenticated at once, 8 if 0
+
+	// minimum time between two logins through the same Proxy (accounts without one share this machine's ip), 0 doesn't limit.
+	// Logins from one ip in quick succession get rate limited (or the accounts flagged) long before hundreds of accounts are done.
+	PerIPInterval time.Duration
+}
+
+// Outcome of authenticating one account of AuthenticateAll.
+type AuthResult struct {
+	Account  *MCaccount
+	Err      error
+	Started  time.Time
+	Duration time.Duration
+}
+
+// Authenticates every account (see Authenticate) with a pool of opts.Workers goroutines, spacing logins through the same proxy by
+// opts.PerIPInterval. The results are in the order of accounts, a failed account doesn't stop the others.
+func AuthenticateAll(accounts []*MCaccount, opts PoolOpts) []AuthResult {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 8
+	}
+	limiter := &ipLimiter{interval: opts.PerIPInterval, next: map[string]time.Time{}}
+
+	results := make([]AuthResult, len(accounts))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				account := accounts[i]
+				limiter.wait(account.Proxy)
+				start := time.Now()
+				err := account.Authenticate()
+				results[i] = AuthResult{Account: account, Err: err, Started: start, Duration: time.Since(start)}
+			}
+		}()
+	}
+	for i := range accounts {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// hands out login slots per proxy, interval apart
+type ipLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time // by proxy url, "" for no proxy
+}
+
+// reserves the next slot of proxy and sleeps until it
+func (l *ipLimiter) wait(proxy string) {
+	if l.interval <= 0 {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[proxy]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[proxy] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
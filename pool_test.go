@@ -0,0 +1,41 @@
+package mcgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestAuthenticateAll(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	var accounts []*MCaccount
+	for _, email := range []string{"pool1@example.com", "pool2@example.com", "pool3@example.com"} {
+		fake := srv.AddAccount(mcgotest.Account{Email: email, Password: "pw", OwnsGame: true})
+		accounts = append(accounts, &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Client: &Client{HTTP: srv.HTTPClient()}})
+	}
+	accounts = append(accounts, &MCaccount{Email: "nobody@example.com", Password: "wrong", Type: Mj, Proxy: "http://other:8080", Client: &Client{HTTP: srv.HTTPClient()}})
+
+	start := time.Now()
+	results := AuthenticateAll(accounts, PoolOpts{Workers: 4, PerIPInterval: time.Millisecond * 30})
+	if len(results) != 4 {
+		t.Fatalf("results: %v | expected one per account", len(results))
+	}
+	for i, result := range results[:3] {
+		if result.Err != nil || result.Account != accounts[i] || !result.Account.Authenticated {
+			t.Fatalf("err: %v | result %v: %+v | expected the account to be authenticated", result.Err, i, result)
+		}
+	}
+	if results[3].Err == nil {
+		t.Fatal("expected the account with the wrong password to fail")
+	}
+	// three logins without a proxy are spaced 30ms apart, the one through another proxy isn't held up by them
+	if elapsed := time.Since(start); elapsed < time.Millisecond*60 {
+		t.Fatalf("elapsed: %v | expected logins from one ip to be spaced", elapsed)
+	}
+	if results[3].Started.Sub(start) > time.Millisecond*20 {
+		t.Fatalf("started after: %v | expected the other proxy to start right away", results[3].Started.Sub(start))
+	}
+}
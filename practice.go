@@ -0,0 +1,60 @@
+package mcgo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// Must be passed to Practice to confirm that a real profile will be created on the account.
+const PracticeConfirmation = "create a real profile on this throwaway account"
+
+type PracticeResult struct {
+	Name     string
+	Result   NameChangeReturn
+	Latency  time.Duration // from the final write to the first byte of the response
+	Lateness time.Duration // how long after the requested time the final write happened
+}
+
+func randomPracticeName() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "mcgo" + hex.EncodeToString(b), nil
+}
+
+// Creates a profile at the given time, measuring the real end to end latency against production.
+// The account must be a throwaway gift code account without a profile, since the create uses up the account's profile.
+// An empty name picks a random one. confirm must be PracticeConfirmation.
+func (account *MCaccount) Practice(name string, at time.Time, confirm string) (PracticeResult, error) {
+	if confirm != PracticeConfirmation {
+		return PracticeResult{}, errors.New("practice creates a real profile, pass PracticeConfirmation to confirm")
+	}
+
+	caps, err := account.Capabilities()
+	if err != nil {
+		return PracticeResult{}, err
+	}
+	if !caps.CanCreateProfile {
+		return PracticeResult{}, errors.New("practice needs an account that owns minecraft and has no profile yet")
+	}
+
+	if name == "" {
+		name, err = randomPracticeName()
+		if err != nil {
+			return PracticeResult{}, err
+		}
+	}
+
+	ret, err := account.ChangeName(name, at, true)
+	result := PracticeResult{Name: name, Result: ret}
+	if !ret.SendTime.IsZero() {
+		result.Lateness = ret.SendTime.Sub(at)
+		if !ret.ReceiveTime.IsZero() {
+			result.Latency = ret.ReceiveTime.Sub(ret.SendTime)
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,28 @@
+package mcgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestPractice(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+
+	if _, err := acc.Practice("", time.Now(), "yes"); err == nil {
+		t.Fatal("expected practice without confirmation to fail")
+	}
+
+	result, err := acc.Practice("", time.Now().Add(time.Millisecond*50), PracticeConfirmation)
+	if err != nil || !result.Result.ChangedName || len(result.Name) > 16 || result.Latency <= 0 {
+		t.Fatalf("err: %v | result: %+v | expected successful practice create", err, result)
+	}
+
+	if _, err := acc.Practice("", time.Now(), PracticeConfirmation); err == nil {
+		t.Fatal("expected practice on an account with a profile to fail")
+	}
+}
@@ -0,0 +1,50 @@
+package mcgo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Waits until offset before dropTime (e.g. 10 minutes), then renews the bearer of every account (refreshing where possible, see KeepAlive)
+// and checks it with ValidateBearer, so the snipe doesn't send with an expired token. Accounts are renewed in parallel.
+// Failed accounts are returned as a *BulkError keyed by their index, ctx being done before the offset returns ctx.Err().
+func PreAuthenticate(ctx context.Context, accounts []*MCaccount, dropTime time.Time, offset time.Duration) error {
+	timer := time.NewTimer(time.Until(dropTime.Add(-offset)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	bulkErr := &BulkError{Total: len(accounts), Errors: map[int]error{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, account := range accounts {
+		wg.Add(1)
+		go func(i int, account *MCaccount) {
+			defer wg.Done()
+			if err := account.preAuthenticate(); err != nil {
+				mu.Lock()
+				bulkErr.Errors[i] = err
+				mu.Unlock()
+			}
+		}(i, account)
+	}
+	wg.Wait()
+
+	if len(bulkErr.Errors) > 0 {
+		return bulkErr
+	}
+	return nil
+}
+
+func (account *MCaccount) preAuthenticate() error {
+	if err := account.reauthenticate(); err != nil {
+		return err
+	}
+	working := account.snapshot()
+	_, err := working.ValidateBearer()
+	return err
+}
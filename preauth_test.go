@@ -0,0 +1,35 @@
+package mcgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestPreAuthenticate(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "pre@example.com", Password: "pw", ClientToken: "token", Name: "Early", OwnsGame: true})
+	good := &MCaccount{Email: fake.Email, Type: Mj, Bearer: fake.Bearer, ClientToken: "token", Client: &Client{HTTP: srv.HTTPClient()}}
+	bad := &MCaccount{Email: "nobody@example.com", Password: "wrong", Type: Mj, Bearer: "revoked", Client: &Client{HTTP: srv.HTTPClient()}}
+
+	old := good.Bearer
+	err := PreAuthenticate(context.Background(), []*MCaccount{good, bad}, time.Now().Add(time.Minute*10), time.Minute*10)
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 1 || bulkErr.Errors[1] == nil {
+		t.Fatalf("err: %v | expected only the second account to fail", err)
+	}
+	if good.Bearer == old {
+		t.Fatal("expected the first account's bearer to be renewed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := PreAuthenticate(ctx, []*MCaccount{good}, time.Now().Add(time.Hour), time.Minute); err != context.Canceled {
+		t.Fatalf("err: %v | expected the wait to stop with ctx", err)
+	}
+}
@@ -0,0 +1,146 @@
+package mcgo
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// handshakes and round trips within this of the median never count as outliers, differences that small are noise
+const outlierMinExcess = time.Millisecond * 25
+
+// dials per connection at most when replacing outliers
+const prewarmAttempts = 3
+
+// request the round trip of a warm connection is measured with, a HEAD response has no body so the connection is left ready for the payload
+const rttProbe = "HEAD / HTTP/1.1\r\nHost: api.minecraftservices.com\r\n\r\n"
+
+// a connection opened ahead of a burst
+type warmConn struct {
+	conn      net.Conn
+	dialStart time.Time
+	dialEnd   time.Time
+	rtt       time.Duration // of the probe request sent after the handshake
+	err       error
+}
+
+func (c warmConn) handshake() time.Duration {
+	return c.dialEnd.Sub(c.dialStart)
+}
+
+func (c warmConn) roundTrip() time.Duration {
+	return c.rtt
+}
+
+func (account *MCaccount) dialWarm() warmConn {
+	c := warmConn{dialStart: account.now()}
+	c.conn, c.err = account.dialer().Dial("tcp", "api.minecraftservices.com:443")
+	c.dialEnd = account.now()
+	if c.err == nil {
+		if c.rtt, c.err = probeRTT(c.conn, account.now); c.err != nil {
+			c.conn.Close()
+			c.conn = nil
+		}
+	}
+	return c
+}
+
+// times one request on conn, which has to stay open for the payload
+func probeRTT(conn net.Conn, now func() time.Time) (time.Duration, error) {
+	start := now()
+	if _, err := io.WriteString(conn, rttProbe); err != nil {
+		return 0, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: "HEAD"})
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.Close {
+		return 0, errors.New("server closed the connection after the latency probe")
+	}
+	return now().Sub(start), nil
+}
+
+func median(conns []warmConn, measure func(warmConn) time.Duration) time.Duration {
+	var values []time.Duration
+	for _, c := range conns {
+		if c.err == nil {
+			values = append(values, measure(c))
+		}
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values[len(values)/2]
+}
+
+// the limit over which measure makes a connection an outlier
+func outlierLimit(conns []warmConn, measure func(warmConn) time.Duration, factor float64) time.Duration {
+	m := median(conns, measure)
+	limit := time.Duration(float64(m) * factor)
+	if limit < m+outlierMinExcess {
+		limit = m + outlierMinExcess
+	}
+	return limit
+}
+
+// Opens n connections at once, times a round trip on each, and replaces the ones whose handshake or round trip took factor times
+// the median or longer, usually a bad route, or that failed. Each connection is dialed up to prewarmAttempts times, keeping the fastest.
+// Returns the connections and how many were replaced.
+func (account *MCaccount) prewarm(n int, factor float64) ([]warmConn, int) {
+	conns := make([]warmConn, n)
+	var wg sync.WaitGroup
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conns[i] = account.dialWarm()
+		}(i)
+	}
+	wg.Wait()
+
+	handshakeLimit := outlierLimit(conns, warmConn.handshake, factor)
+	rttLimit := outlierLimit(conns, warmConn.roundTrip, factor)
+	slower := func(a, b warmConn) bool { return a.handshake()+a.rtt >= b.handshake()+b.rtt }
+
+	replaced := make([]bool, n)
+	for attempt := 1; attempt < prewarmAttempts; attempt++ {
+		for i := range conns {
+			if conns[i].err == nil && conns[i].handshake() < handshakeLimit && conns[i].rtt < rttLimit {
+				continue
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				fresh := account.dialWarm()
+				if fresh.err != nil || conns[i].err == nil && slower(fresh, conns[i]) {
+					if fresh.conn != nil {
+						fresh.conn.Close()
+					}
+					return
+				}
+				if conns[i].conn != nil {
+					conns[i].conn.Close()
+				}
+				conns[i] = fresh
+				replaced[i] = true
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	count := 0
+	for _, r := range replaced {
+		if r {
+			count++
+		}
+	}
+	return conns, count
+}
@@ -0,0 +1,266 @@
+package mcgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Skin struct {
+	ID      string `json:"id"`
+	State   string `json:"state"`
+	URL     string `json:"url"`
+	Variant string `json:"variant"`
+	Alias   string `json:"alias"`
+}
+
+// texture hash of the skin, the last path segment of its textures.minecraft.net url
+func (skin Skin) Hash() string {
+	return skin.URL[strings.LastIndex(skin.URL, "/")+1:]
+}
+
+type Cape struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
+	URL   string `json:"url"`
+	Alias string `json:"alias"`
+}
+
+// minecraft profile of an account, as returned by api.minecraftservices.com/minecraft/profile
+type Profile struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Skins []Skin `json:"skins"`
+	Capes []Cape `json:"capes"`
+
+	Privileges map[string]bool `json:"privileges,omitempty"` // not part of the profile response, filled in from the player attributes by the monitor
+}
+
+// returns the skin currently in use, ok is false if the profile has no active skin
+func (profile Profile) ActiveSkin() (Skin, bool) {
+	for _, skin := range profile.Skins {
+		if skin.State == "ACTIVE" {
+			return skin, true
+		}
+	}
+	return Skin{}, false
+}
+
+// returns the cape currently shown, ok is false if the profile shows no cape
+func (profile Profile) ActiveCape() (Cape, bool) {
+	for _, cape := range profile.Capes {
+		if cape.State == "ACTIVE" {
+			return cape, true
+		}
+	}
+	return Cape{}, false
+}
+
+// A skin seen on an account, FirstSeen and LastSeen are the times of the first and last profile fetch that returned it.
+type SkinSnapshot struct {
+	Hash      string
+	Variant   string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// grab the minecraft profile of the account. Every fetch records the active skin in the account's skin history.
+func (account *MCaccount) FetchProfile() (Profile, error) {
+	req, err := account.AuthenticatedReq("GET", "https://api.minecraftservices.com/minecraft/profile", nil)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	resp, err := account.do(req)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return Profile{}, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        errors.New("account does not own minecraft"),
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		return Profile{}, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when requesting profile", resp.Status),
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var profile Profile
+	err = json.Unmarshal(respBytes, &profile)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	account.recordSkin(profile, account.now())
+
+	return profile, nil
+}
+
+// Returned by CreateProfile when minecraftservices refuses the name or the account, Reason is the status it gives:
+// DUPLICATE, NOT_ALLOWED, ALREADY_REGISTERED (the account has a profile) or NOT_ENTITLED (it doesn't own the game).
+type CreateProfileError struct {
+	StatusCode int
+	Reason     string
+}
+
+func (e *CreateProfileError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("creating profile failed with status %v", e.StatusCode)
+	}
+	return fmt.Sprintf("creating profile failed: %v", e.Reason)
+}
+
+type createProfileBody struct {
+	ProfileName string `json:"profileName"`
+}
+
+// Sends a request minecraftservices answers with the profile. Refusals (statuses >= 400) aren't errors here: the status is returned with the
+// reason from the error details, so callers can wrap them in their own error.
+func (account *MCaccount) profileRequest(method, endpoint string, body io.Reader) (profile Profile, status int, reason string, err error) {
+	req, err := account.AuthenticatedReq(method, endpoint, body)
+	if err != nil {
+		return Profile{}, 0, "", err
+	}
+
+	resp, err := account.do(req)
+	if err != nil {
+		return Profile{}, 0, "", err
+	}
+
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Profile{}, 0, "", err
+	}
+
+	if resp.StatusCode >= 400 {
+		var errResp struct {
+			Details struct {
+				Status string `json:"status"`
+			} `json:"details"`
+		}
+		json.Unmarshal(respBytes, &errResp)
+		return Profile{}, resp.StatusCode, errResp.Details.Status, nil
+	}
+
+	err = json.Unmarshal(respBytes, &profile)
+	return profile, resp.StatusCode, "", err
+}
+
+// Creates the account's profile with name right away, for gift code and game pass accounts that just need one.
+// Unlike ChangeName with createProfile it goes through the account's http client instead of a timed raw connection.
+func (account *MCaccount) CreateProfile(name string) (Profile, error) {
+	if err := account.checkWritable(); err != nil {
+		return Profile{}, err
+	}
+	if err := CheckName(name); err != nil {
+		return Profile{}, err
+	}
+
+	body, err := json.Marshal(createProfileBody{ProfileName: name})
+	if err != nil {
+		return Profile{}, err
+	}
+
+	profile, status, reason, err := account.profileRequest("POST", "https://api.minecraftservices.com/minecraft/profile", bytes.NewReader(body))
+	if err != nil {
+		return Profile{}, err
+	}
+	if status >= 400 {
+		return Profile{}, &CreateProfileError{StatusCode: status, Reason: reason}
+	}
+
+	account.recordOwnProfile(profile)
+	return profile, nil
+}
+
+// Returned by ChangeNameNow when minecraftservices refuses the name, Reason is the status it gives (DUPLICATE, NOT_ALLOWED),
+// empty for other refusals like a missing profile.
+type RenameError struct {
+	StatusCode int
+	Reason     string
+}
+
+func (e *RenameError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("changing name failed with status %v", e.StatusCode)
+	}
+	return fmt.Sprintf("changing name failed: %v", e.Reason)
+}
+
+// Changes the account's name right away through the account's http client and returns the renamed profile, for renames that don't
+// need ChangeName's timed raw connection. A name change on cooldown returns a *NameChangeNotAllowedError.
+func (account *MCaccount) ChangeNameNow(name string) (Profile, error) {
+	if err := account.checkWritable(); err != nil {
+		return Profile{}, err
+	}
+	if err := CheckName(name); err != nil {
+		return Profile{}, err
+	}
+
+	profile, status, reason, err := account.profileRequest("PUT", "https://api.minecraftservices.com/minecraft/profile/name/"+url.PathEscape(name), nil)
+	if err != nil {
+		return Profile{}, err
+	}
+	if status == 403 && reason == "" {
+		// like for ChangeName, a 403 without details usually means the name can't be changed yet
+		if allowedAt, err := account.NameChangeAllowedAt(); err == nil && !allowedAt.IsZero() {
+			return Profile{}, &NameChangeNotAllowedError{AllowedAt: allowedAt}
+		}
+	}
+	if status >= 400 {
+		return Profile{}, &RenameError{StatusCode: status, Reason: reason}
+	}
+
+	account.recordOwnProfile(profile)
+	return profile, nil
+}
+
+func (account *MCaccount) recordSkin(profile Profile, seen time.Time) {
+	skin, ok := profile.ActiveSkin()
+	if !ok {
+		return
+	}
+
+	if n := len(account.SkinHistory); n > 0 && account.SkinHistory[n-1].Hash == skin.Hash() {
+		account.SkinHistory[n-1].LastSeen = seen
+		return
+	}
+
+	account.SkinHistory = append(account.SkinHistory, SkinSnapshot{
+		Hash:      skin.Hash(),
+		Variant:   skin.Variant,
+		FirstSeen: seen,
+		LastSeen:  seen,
+	})
+}
+
+// returns the skins seen on this account since the given time, oldest first
+func (account *MCaccount) SkinsSince(since time.Time) []SkinSnapshot {
+	var snapshots []SkinSnapshot
+	for _, snapshot := range account.SkinHistory {
+		if !snapshot.LastSeen.Before(since) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
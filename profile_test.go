@@ -0,0 +1,85 @@
+package mcgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestRecordSkin(t *testing.T) {
+	acc := MCaccount{}
+	steve := Profile{Skins: []Skin{{State: "ACTIVE", URL: "http://textures.minecraft.net/texture/abc", Variant: "CLASSIC"}}}
+	alex := Profile{Skins: []Skin{{State: "ACTIVE", URL: "http://textures.minecraft.net/texture/def", Variant: "SLIM"}}}
+
+	start := time.Now()
+	acc.recordSkin(steve, start)
+	acc.recordSkin(steve, start.Add(time.Minute))
+	acc.recordSkin(alex, start.Add(time.Hour))
+
+	if len(acc.SkinHistory) != 2 {
+		t.Fatalf("history: %v | expected 2 snapshots", acc.SkinHistory)
+	}
+	if acc.SkinHistory[0].Hash != "abc" || !acc.SkinHistory[0].LastSeen.Equal(start.Add(time.Minute)) {
+		t.Fatalf("first snapshot: %+v", acc.SkinHistory[0])
+	}
+	if since := acc.SkinsSince(start.Add(time.Minute * 30)); len(since) != 1 || since[0].Hash != "def" {
+		t.Fatalf("skins since: %v", since)
+	}
+}
+
+func TestCreateProfile(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	srv.AddAccount(mcgotest.Account{Name: "Taken", OwnsGame: true})
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "giftcode@example.com", OwnsGame: true})
+	var createErr *CreateProfileError
+	if _, err := acc.CreateProfile("Taken"); !errors.As(err, &createErr) || createErr.Reason != "DUPLICATE" {
+		t.Fatalf("err: %v | expected the taken name to be a duplicate", err)
+	}
+
+	profile, err := acc.CreateProfile("Fresh")
+	if err != nil || profile.Name != "Fresh" || acc.Username != "Fresh" || acc.UUID != profile.ID {
+		t.Fatalf("err: %v | profile: %+v | username: %v | expected the profile to be created", err, profile, acc.Username)
+	}
+	if _, err := acc.CreateProfile("Second"); !errors.As(err, &createErr) || createErr.Reason != "ALREADY_REGISTERED" {
+		t.Fatalf("err: %v | expected a second profile to be refused", err)
+	}
+
+	unowned := newFakeAccount(srv, mcgotest.Account{Email: "unowned@example.com"})
+	if _, err := unowned.CreateProfile("Another"); !errors.As(err, &createErr) || createErr.Reason != "NOT_ENTITLED" {
+		t.Fatalf("err: %v | expected accounts without the game to be refused", err)
+	}
+	if _, err := unowned.CreateProfile("no spaces"); err == nil {
+		t.Fatal("expected an invalid name to be refused without a request")
+	}
+}
+
+func TestChangeNameNow(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	srv.AddAccount(mcgotest.Account{Name: "Taken", OwnsGame: true})
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "rename@example.com", Name: "Before", OwnsGame: true, NameChangeAllowed: true})
+	var renameErr *RenameError
+	if _, err := acc.ChangeNameNow("Taken"); !errors.As(err, &renameErr) || renameErr.Reason != "DUPLICATE" {
+		t.Fatalf("err: %v | expected the taken name to be a duplicate", err)
+	}
+
+	profile, err := acc.ChangeNameNow("After")
+	if err != nil || profile.Name != "After" || acc.Username != "After" {
+		t.Fatalf("err: %v | profile: %+v | expected the rename to go through", err, profile)
+	}
+
+	var cooldownErr *NameChangeNotAllowedError
+	if _, err := acc.ChangeNameNow("Again"); !errors.As(err, &cooldownErr) || cooldownErr.AllowedAt.IsZero() {
+		t.Fatalf("err: %v | expected the second rename to be on cooldown", err)
+	}
+
+	noProfile := newFakeAccount(srv, mcgotest.Account{Email: "noprofile@example.com", OwnsGame: true})
+	if _, err := noProfile.ChangeNameNow("Other"); !errors.As(err, &renameErr) || renameErr.StatusCode != 404 {
+		t.Fatalf("err: %v | expected accounts without a profile to get a 404", err)
+	}
+}
@@ -0,0 +1,47 @@
+package mcgo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// How often WaitForProfile checks the lookups.
+var ProfilePollInterval = time.Millisecond * 500
+
+var ErrProfileNotPropagated = errors.New("profile change did not propagate before the timeout")
+
+// Waits until a new name of the account shows up everywhere: the public lookup of expectedName returns the account's UUID and the
+// authenticated profile has the name. Returns how long that took, call it right after the change, before claiming NameMC or joining servers.
+// Lookups that fail (ratelimits, network) are retried until timeout or ctx is done.
+func (account *MCaccount) WaitForProfile(ctx context.Context, expectedName string, timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		if account.profilePropagated(expectedName) {
+			return time.Since(start), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return time.Since(start), ErrProfileNotPropagated
+			}
+			return time.Since(start), ctx.Err()
+		case <-time.After(ProfilePollInterval):
+		}
+	}
+}
+
+func (account *MCaccount) profilePropagated(expectedName string) bool {
+	profile, err := account.FetchProfile()
+	if err != nil || !strings.EqualFold(profile.Name, expectedName) {
+		return false
+	}
+
+	public, owned, err := account.publicLookup(expectedName)
+	return err == nil && owned && public.ID == profile.ID
+}
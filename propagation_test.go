@@ -0,0 +1,26 @@
+package mcgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestWaitForProfile(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Name: "Before", OwnsGame: true, NameChangeAllowed: true})
+	if _, err := acc.ChangeName("After", time.Now(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := acc.WaitForProfile(context.Background(), "After", time.Second); err != nil {
+		t.Fatalf("err: %v | expected the new name to be visible", err)
+	}
+	if _, err := acc.WaitForProfile(context.Background(), "Before", ProfilePollInterval/2); err != ErrProfileNotPropagated {
+		t.Fatalf("err: %v | expected the old name to time out", err)
+	}
+}
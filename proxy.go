@@ -0,0 +1,189 @@
+package mcgo
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// parses a proxy url as ImportCSV, FromEnv and ApplyProxies take them, http(s):// or socks5:// with an optional user:pass
+func parseProxy(proxy string) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url %q", proxy)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+		return proxyURL, nil
+	}
+	return nil, fmt.Errorf("unsupported proxy scheme %q, use http, https or socks5", proxyURL.Scheme)
+}
+
+var defaultProxyPorts = map[string]string{"http": "80", "https": "443", "socks5": "1080"}
+
+// Dialer tunneling name change connections through a proxy (CONNECT for http proxies) before the TLS handshake with the target.
+type proxyDialer struct {
+	proxy  *url.URL
+	config *tls.Config // config of the handshake with the target, ServerName is filled in. Verifies the target if nil.
+}
+
+// Returns a Dialer sending name change connections through proxy, the counterpart of TransportOptions.Proxy for payloads.
+// Accounts imported with a proxy (ImportCSV, FromEnv, ApplyProxies) get one, so every request of the account leaves from the proxy's ip.
+func NewProxyDialer(proxy string) (Dialer, error) {
+	proxyURL, err := parseProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	return proxyDialer{proxy: proxyURL}, nil
+}
+
+func (d proxyDialer) Dial(network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyAddr := d.proxy.Host
+	if d.proxy.Port() == "" {
+		proxyAddr = net.JoinHostPort(d.proxy.Hostname(), defaultProxyPorts[d.proxy.Scheme])
+	}
+	raw, err := DefaultDNSCache.DialContext(context.Background(), network, proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnel := raw
+	if d.proxy.Scheme == "https" {
+		tunnel = tls.Client(raw, &tls.Config{ServerName: d.proxy.Hostname()})
+	}
+	if d.proxy.Scheme == "socks5" {
+		err = socksConnect(tunnel, addr, d.proxy.User)
+	} else {
+		err = httpConnect(tunnel, addr, d.proxy.User)
+	}
+	if err != nil {
+		raw.Close()
+		return nil, err
+	}
+
+	config := &tls.Config{}
+	if d.config != nil {
+		config = d.config.Clone()
+	}
+	config.ServerName = host
+	conn := tls.Client(tunnel, config)
+	if err := conn.Handshake(); err != nil {
+		raw.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// asks an http proxy to open a tunnel to addr
+func httpConnect(conn net.Conn, addr string, user *url.Userinfo) error {
+	req := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if user != nil {
+		password, _ := user.Password()
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password)))
+	}
+	if err := req.Write(conn); err != nil {
+		return err
+	}
+
+	// the proxy sends nothing after its response until the handshake starts, so the reader can't hold back tunnel bytes
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("proxy refused the tunnel to %v: %v", addr, resp.Status)
+	}
+	return nil
+}
+
+// asks a socks5 proxy (RFC 1928, with RFC 1929 username/password auth) to connect to addr
+func socksConnect(conn net.Conn, addr string, user *url.Userinfo) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || len(host) > 255 {
+		return fmt.Errorf("invalid socks5 target %q", addr)
+	}
+
+	method := byte(0)
+	if user != nil {
+		method = 2
+	}
+	if _, err := conn.Write([]byte{5, 1, method}); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != 5 || reply[1] != method {
+		return fmt.Errorf("socks5 proxy refused auth method %v", method)
+	}
+
+	if user != nil {
+		password, _ := user.Password()
+		auth := append([]byte{1, byte(len(user.Username()))}, user.Username()...)
+		auth = append(append(auth, byte(len(password))), password...)
+		if _, err := conn.Write(auth); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(conn, reply); err != nil {
+			return err
+		}
+		if reply[1] != 0 {
+			return fmt.Errorf("socks5 proxy rejected the credentials of %v", user.Username())
+		}
+	}
+
+	connect := append([]byte{5, 1, 0, 3, byte(len(host))}, host...)
+	connect = append(connect, byte(port>>8), byte(port))
+	if _, err := conn.Write(connect); err != nil {
+		return err
+	}
+
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return err
+	}
+	if head[1] != 0 {
+		return fmt.Errorf("socks5 proxy refused the connection to %v with code %v", addr, head[1])
+	}
+
+	// the bound address isn't needed, but has to be read past
+	var skip int
+	switch head[3] {
+	case 1:
+		skip = 4
+	case 4:
+		skip = 16
+	case 3:
+		if _, err := io.ReadFull(conn, reply[:1]); err != nil {
+			return err
+		}
+		skip = int(reply[0])
+	default:
+		return fmt.Errorf("socks5 proxy replied with address type %v", head[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, skip+2))
+	return err
+}
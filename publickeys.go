@@ -0,0 +1,138 @@
+package mcgo
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// How long fetched public keys are reused before PublicKeys requests them again.
+var PublicKeysTTL = time.Hour * 24
+
+// Mojang's public keys, used to verify signed profile properties (textures) and player chat certificates.
+type MojangPublicKeys struct {
+	ProfilePropertyKeys   []*rsa.PublicKey
+	PlayerCertificateKeys []*rsa.PublicKey
+	FetchedAt             time.Time
+}
+
+type publicKeysResponse struct {
+	ProfilePropertyKeys []struct {
+		PublicKey string `json:"publicKey"`
+	} `json:"profilePropertyKeys"`
+	PlayerCertificateKeys []struct {
+		PublicKey string `json:"publicKey"`
+	} `json:"playerCertificateKeys"`
+}
+
+var (
+	publicKeysMu     sync.Mutex
+	cachedPublicKeys *MojangPublicKeys
+)
+
+// returns Mojang's public keys, fetching them from minecraftservices if the cached copy is missing or older than PublicKeysTTL
+func PublicKeys() (MojangPublicKeys, error) {
+	publicKeysMu.Lock()
+	defer publicKeysMu.Unlock()
+
+	if cachedPublicKeys != nil && time.Since(cachedPublicKeys.FetchedAt) < PublicKeysTTL {
+		return *cachedPublicKeys, nil
+	}
+
+	keys, err := fetchPublicKeys()
+	if err != nil {
+		return MojangPublicKeys{}, err
+	}
+
+	cachedPublicKeys = &keys
+	return keys, nil
+}
+
+// drops the cached public keys, the next call to PublicKeys will fetch them again
+func ResetPublicKeys() {
+	publicKeysMu.Lock()
+	cachedPublicKeys = nil
+	publicKeysMu.Unlock()
+}
+
+func fetchPublicKeys() (MojangPublicKeys, error) {
+	resp, err := DefaultClient.Get("https://api.minecraftservices.com/publickeys")
+	if err != nil {
+		return MojangPublicKeys{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return MojangPublicKeys{}, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when requesting public keys", resp.Status),
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return MojangPublicKeys{}, err
+	}
+
+	var respJson publicKeysResponse
+	err = json.Unmarshal(respBytes, &respJson)
+	if err != nil {
+		return MojangPublicKeys{}, err
+	}
+
+	keys := MojangPublicKeys{FetchedAt: time.Now()}
+
+	for _, k := range respJson.ProfilePropertyKeys {
+		key, err := parsePublicKey(k.PublicKey)
+		if err != nil {
+			return MojangPublicKeys{}, err
+		}
+		keys.ProfilePropertyKeys = append(keys.ProfilePropertyKeys, key)
+	}
+
+	for _, k := range respJson.PlayerCertificateKeys {
+		key, err := parsePublicKey(k.PublicKey)
+		if err != nil {
+			return MojangPublicKeys{}, err
+		}
+		keys.PlayerCertificateKeys = append(keys.PlayerCertificateKeys, key)
+	}
+
+	return keys, nil
+}
+
+// like parsePublicKey, for keys built into mcgo
+func mustParsePublicKey(encoded string) *rsa.PublicKey {
+	key, err := parsePublicKey(encoded)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// parses a base64 encoded DER (PKIX) public key, the format minecraftservices returns keys in
+func parsePublicKey(encoded string) (*rsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an RSA key")
+	}
+
+	return rsaPub, nil
+}
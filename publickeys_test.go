@@ -0,0 +1,29 @@
+package mcgo
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := parsePublicKey(base64.StdEncoding.EncodeToString(der))
+	if err != nil || key.N.Cmp(priv.PublicKey.N) != 0 {
+		t.Fatalf("err: %v | key: %v | expected key: %v", err, key, priv.PublicKey)
+	}
+
+	if _, err := parsePublicKey("not base64!"); err == nil {
+		t.Fatal("expected error for invalid key")
+	}
+}
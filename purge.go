@@ -0,0 +1,170 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Storage PurgeAccount deletes an account's data from, empty fields are skipped.
+type PurgeTargets struct {
+	CachePath       string // token cache written by SaveCache
+	CachePassphrase string
+	Budget          *RequestBudget  // request counts of the account
+	ReceiptDir      string          // directory WriteReceipt wrote receipts of the account's profile to
+	Credentials     CredentialStore // store StoreCredentials saved the account's password and refresh token to
+}
+
+// What PurgeAccount deleted.
+type PurgeSummary struct {
+	Credentials  []string // names of the account fields that were cleared
+	CachedTokens bool     // the account had an entry in the token cache
+	BudgetCounts bool     // the budget had counts of the account
+	Receipts     []string // paths of the removed receipts
+	Stored       []string // keys deleted from the credential store
+	Results      int      // name change attempts of the account removed from RecentResults
+}
+
+// Deletes everything mcgo keeps of an account: its credentials, tokens, identity and history in memory (only Email, Type and the
+// transport are kept, so the summary and errors can still name it), its name change attempts in RecentResults, its entry in the token
+// cache, its request counts, its receipts and its secrets in the credential store.
+// Storage that failed is reported in the error, what was deleted before that is in the summary.
+func PurgeAccount(account *MCaccount, targets PurgeTargets) (PurgeSummary, error) {
+	var summary PurgeSummary
+	email, uuid := account.Email, account.UUID
+
+	if targets.CachePath != "" {
+		tokens, err := readCache(targets.CachePath, targets.CachePassphrase)
+		if err != nil {
+			return summary, err
+		}
+		key := cacheKey(account)
+		if _, ok := tokens[key]; key != "" && ok {
+			delete(tokens, key)
+			if err := writeCache(targets.CachePath, targets.CachePassphrase, tokens); err != nil {
+				return summary, err
+			}
+			summary.CachedTokens = true
+		}
+	}
+
+	// accounts without a key would share it with every other one
+	if key := budgetKey(account); targets.Budget != nil && key != "" {
+		forgot, err := targets.Budget.forget(key)
+		if err != nil {
+			return summary, err
+		}
+		summary.BudgetCounts = forgot
+	}
+
+	if targets.ReceiptDir != "" && uuid != "" {
+		removed, err := purgeReceipts(targets.ReceiptDir, uuid)
+		summary.Receipts = removed
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	if targets.Credentials != nil {
+		stored, err := account.deleteCredentials(targets.Credentials)
+		summary.Stored = stored
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	summary.Results = forgetResults(email, uuid)
+	summary.Credentials = account.clearCredentials()
+	return summary, nil
+}
+
+// removes the receipts in dir issued for the profile uuid
+func purgeReceipts(dir, uuid string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return removed, err
+		}
+		var r Receipt
+		if json.Unmarshal(data, &r) != nil || r.Signature == "" || !strings.EqualFold(r.ProfileID, uuid) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// deletes the secrets of account from store, returning the keys that were stored
+func (account *MCaccount) deleteCredentials(store CredentialStore) ([]string, error) {
+	if CredentialKey(account, "") == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for field := range account.credentialFields() {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var deleted []string
+	for _, field := range fields {
+		key := CredentialKey(account, field)
+		_, err := store.Get(key)
+		if err == ErrCredentialNotFound {
+			continue
+		}
+		if err != nil {
+			return deleted, err
+		}
+		if err := store.Delete(key); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, key)
+	}
+	return deleted, nil
+}
+
+// zeroes every field holding a secret or data about the account, returning the names of those that were set
+func (account *MCaccount) clearCredentials() []string {
+	lock := account.authLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	var cleared []string
+	note := func(name string, set bool) {
+		if set {
+			cleared = append(cleared, name)
+		}
+	}
+	note("Password", account.Password != "")
+	note("SecurityQuestions", len(account.SecurityQuestions) > 0)
+	note("SecurityAnswers", len(account.SecurityAnswers) > 0)
+	note("AnswersByQuestion", len(account.AnswersByQuestion) > 0)
+	note("Bearer", account.Bearer != "")
+	note("ClientToken", account.ClientToken != "")
+	note("MsRefreshToken", account.MsRefreshToken != "")
+	note("XblToken", account.XblToken != "")
+	note("XUID", account.XUID != "")
+	note("Gamertag", account.Gamertag != "")
+	note("UUID", account.UUID != "")
+	note("Username", account.Username != "")
+	note("SkinHistory", len(account.SkinHistory) > 0)
+	note("LastProfile", account.LastProfile != nil)
+	note("Tags", len(account.Tags) > 0)
+	note("Proxy", account.Proxy != "")
+
+	*account = MCaccount{Email: account.Email, Type: account.Type, Client: account.Client, Dialer: account.Dialer, auth: account.auth}
+	return cleared
+}
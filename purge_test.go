@@ -0,0 +1,132 @@
+package mcgo
+
+import (
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPurgeAccount(t *testing.T) {
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "tokens.cache")
+	budget, err := NewRequestBudget(10, filepath.Join(dir, "budget.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acc := &MCaccount{Email: "purge@example.com", Password: "pw", Bearer: "bearer", MsRefreshToken: "refresh", UUID: "uuid1", Username: "Purged", Type: Ms, Tags: []string{"eu"}}
+	kept := &MCaccount{Email: "kept@example.com", Bearer: "kept", UUID: "uuid2"}
+	if err := SaveCache(cachePath, "pass", []*MCaccount{acc, kept}); err != nil {
+		t.Fatal(err)
+	}
+	if err := budget.spend(budgetKey(acc)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, key, _ := ed25519.GenerateKey(nil)
+	var receipts []string
+	for _, a := range []*MCaccount{acc, kept} {
+		r, err := NewReceipt(NameChangeReturn{Account: *a, Username: a.Username + "x", ChangedName: true, SendTime: time.Now()}, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path, err := WriteReceipt(dir, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		receipts = append(receipts, path)
+	}
+
+	store := mapStore{}
+	for _, a := range []*MCaccount{acc, kept} {
+		if err := a.StoreCredentials(store); err != nil {
+			t.Fatal(err)
+		}
+		recordResult(NameChangeReturn{Account: *a, Username: "Attempt", StatusCode: 403})
+	}
+
+	summary, err := PurgeAccount(acc, PurgeTargets{CachePath: cachePath, CachePassphrase: "pass", Budget: budget, ReceiptDir: dir, Credentials: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !summary.CachedTokens || !summary.BudgetCounts || !reflect.DeepEqual(summary.Receipts, receipts[:1]) {
+		t.Fatalf("summary: %+v | expected the cache entry, budget counts and first receipt to be deleted", summary)
+	}
+	if want := []string{"Password", "Bearer", "MsRefreshToken", "UUID", "Username", "Tags"}; !reflect.DeepEqual(summary.Credentials, want) {
+		t.Fatalf("cleared: %v | expected %v", summary.Credentials, want)
+	}
+	if acc.Password != "" || acc.Bearer != "" || acc.Email != "purge@example.com" || acc.Type != Ms {
+		t.Fatalf("account: %+v | expected only email and type to be kept", acc)
+	}
+
+	if want := []string{"purge@example.com:msRefreshToken", "purge@example.com:password"}; !reflect.DeepEqual(summary.Stored, want) || len(store) != 0 {
+		t.Fatalf("deleted: %v | store: %v | expected %v to be deleted", summary.Stored, store, want)
+	}
+	if summary.Results != 1 {
+		t.Fatalf("results: %v | expected the account's attempt to be dropped", summary.Results)
+	}
+	for _, ret := range RecentResults() {
+		if ret.Account.Email == acc.Email {
+			t.Fatalf("result: %+v | expected no attempts of the purged account", ret)
+		}
+	}
+
+	if _, err := os.Stat(receipts[1]); err != nil {
+		t.Fatalf("err: %v | expected receipts of other profiles to be kept", err)
+	}
+	restored := &MCaccount{Email: acc.Email}
+	other := &MCaccount{Email: kept.Email}
+	if err := LoadCache(cachePath, "pass", []*MCaccount{restored, other}); err != nil || restored.Bearer != "" || other.Bearer != "kept" {
+		t.Fatalf("err: %v | purged: %q, kept: %q | expected only the purged account to leave the cache", err, restored.Bearer, other.Bearer)
+	}
+	if budget.Remaining(acc.Email) != 10 {
+		t.Fatal("expected the budget counts to be deleted")
+	}
+
+	// purging again finds nothing left
+	summary, err = PurgeAccount(acc, PurgeTargets{CachePath: cachePath, CachePassphrase: "pass", Budget: budget, ReceiptDir: dir, Credentials: store})
+	if err != nil || summary.CachedTokens || summary.BudgetCounts || len(summary.Receipts) != 0 || len(summary.Credentials) != 0 || len(summary.Stored) != 0 || summary.Results != 0 {
+		t.Fatalf("err: %v | summary: %+v | expected nothing to purge", err, summary)
+	}
+}
+
+func TestPurgeAccountWithoutEmail(t *testing.T) {
+	budget, err := NewRequestBudget(10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := mapStore{}
+
+	purged := &MCaccount{Bearer: "purged", UUID: "uuid1", MsRefreshToken: "refresh"}
+	kept := &MCaccount{Bearer: "kept", UUID: "uuid2", MsRefreshToken: "other"}
+	for _, account := range []*MCaccount{purged, kept} {
+		if err := budget.spend(budgetKey(account)); err != nil {
+			t.Fatal(err)
+		}
+		if err := account.StoreCredentials(store); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if store["uuid:uuid1:msRefreshToken"] != "refresh" || store["uuid:uuid2:msRefreshToken"] != "other" {
+		t.Fatalf("store: %v | expected the credentials stored by uuid", store)
+	}
+
+	summary, err := PurgeAccount(purged, PurgeTargets{Budget: budget, Credentials: store})
+	if err != nil || !summary.BudgetCounts || !reflect.DeepEqual(summary.Stored, []string{"uuid:uuid1:msRefreshToken"}) {
+		t.Fatalf("err: %v | summary: %+v | expected the counts and credentials of the uuid to be purged", err, summary)
+	}
+	if budget.Remaining("uuid:uuid2") != 9 || store["uuid:uuid2:msRefreshToken"] != "other" {
+		t.Fatalf("remaining: %v | store: %v | expected the other account to be kept", budget.Remaining("uuid:uuid2"), store)
+	}
+
+	// an account without a key has nothing of its own to purge
+	if summary, err := PurgeAccount(&MCaccount{Bearer: "opaque"}, PurgeTargets{Budget: budget, Credentials: store}); err != nil || summary.BudgetCounts || len(summary.Stored) != 0 {
+		t.Fatalf("err: %v | summary: %+v | expected nothing to be purged", err, summary)
+	}
+	if err := (&MCaccount{Bearer: "opaque"}).StoreCredentials(store); err == nil {
+		t.Fatal("expected storing credentials without an email or uuid to fail")
+	}
+}
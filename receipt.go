@@ -0,0 +1,135 @@
+package mcgo
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Evidence of when and how a name was obtained, signed with the key of the tool that made the change.
+// Anyone can check that it wasn't altered with Verify, that it was issued by a given tool by comparing PublicKey with the key that tool publishes.
+type Receipt struct {
+	Version          int       `json:"version"`
+	Username         string    `json:"username"`
+	ProfileID        string    `json:"profileId"`
+	Account          string    `json:"account"` // masked email
+	StatusCode       int       `json:"statusCode"`
+	DialStart        time.Time `json:"dialStart"`
+	PartialWriteTime time.Time `json:"partialWriteTime"`
+	SendTime         time.Time `json:"sendTime"`
+	ReceiveTime      time.Time `json:"receiveTime"`
+	ResponseHeadHash string    `json:"responseHeadHash"`
+	IssuedAt         time.Time `json:"issuedAt"`
+	PublicKey        string    `json:"publicKey"` // hex ed25519 public key
+	Signature        string    `json:"signature"` // hex ed25519 signature of the receipt without it
+}
+
+var ErrReceiptSignature = errors.New("receipt signature does not match its contents")
+
+func hashResponseHead(raw []byte) string {
+	head := responseHead(raw)
+	if head == nil {
+		return ""
+	}
+	sum := sha256.Sum256(head)
+	return hex.EncodeToString(sum[:])
+}
+
+// bytes the signature is made over, the json of the receipt with an empty signature
+func (r Receipt) signedBytes() ([]byte, error) {
+	r.Signature = ""
+	return json.Marshal(r)
+}
+
+// Makes a receipt for a successful name change, signed with key.
+func NewReceipt(ret NameChangeReturn, key ed25519.PrivateKey) (Receipt, error) {
+	if !ret.ChangedName {
+		return Receipt{}, errors.New("receipts can only be made for successful name changes")
+	}
+
+	r := Receipt{
+		Version:          1,
+		Username:         ret.Username,
+		ProfileID:        ret.Account.UUID,
+		Account:          maskEmail(ret.Account.Email),
+		StatusCode:       ret.StatusCode,
+		DialStart:        ret.DialStart,
+		PartialWriteTime: ret.PartialWriteTime,
+		SendTime:         ret.SendTime,
+		ReceiveTime:      ret.ReceiveTime,
+		ResponseHeadHash: ret.ResponseHeadHash,
+		IssuedAt:         ret.Account.now(),
+		PublicKey:        hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+
+	signed, err := r.signedBytes()
+	if err != nil {
+		return Receipt{}, err
+	}
+	r.Signature = hex.EncodeToString(ed25519.Sign(key, signed))
+	return r, nil
+}
+
+// checks that the receipt is signed by its PublicKey and unaltered
+func (r Receipt) Verify() error {
+	pub, err := hex.DecodeString(r.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return ErrReceiptSignature
+	}
+	sig, err := hex.DecodeString(r.Signature)
+	if err != nil {
+		return ErrReceiptSignature
+	}
+	signed, err := r.signedBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), signed, sig) {
+		return ErrReceiptSignature
+	}
+	return nil
+}
+
+// Writes the receipt to dir as <username>-<send time>.json, returning the path.
+func WriteReceipt(dir string, r Receipt) (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%v-%v.json", strings.ToLower(r.Username), r.SendTime.UnixNano()))
+	return path, ioutil.WriteFile(path, data, 0644)
+}
+
+// Loads the receipt signing key stored at path, generating and storing one if there is none yet. Keep the file private,
+// publish the public key (hex of key.Public()) so others can check receipts came from you.
+func LoadOrCreateReceiptKey(path string) (ed25519.PrivateKey, error) {
+	seed, err := ioutil.ReadFile(path)
+	if err == nil {
+		decoded, err := hex.DecodeString(strings.TrimSpace(string(seed)))
+		if err != nil || len(decoded) != ed25519.SeedSize {
+			return nil, fmt.Errorf("%v is not a receipt key", path)
+		}
+		return ed25519.NewKeyFromSeed(decoded), nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(key.Seed())), 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,51 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestReceipt(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "seller@example.com", OwnsGame: true})
+	ret, err := acc.ChangeName("Receipt", time.Now(), true)
+	if err != nil || ret.ResponseHeadHash == "" {
+		t.Fatalf("err: %v | head hash: %v | expected a hashed response", err, ret.ResponseHeadHash)
+	}
+
+	dir := t.TempDir()
+	key, err := LoadOrCreateReceiptKey(filepath.Join(dir, "receipt.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := LoadOrCreateReceiptKey(filepath.Join(dir, "receipt.key")); err != nil || !again.Equal(key) {
+		t.Fatalf("err: %v | expected the stored key to be loaded", err)
+	}
+
+	receipt, err := NewReceipt(ret, key)
+	if err != nil || receipt.Account != "se***@example.com" {
+		t.Fatalf("err: %v | receipt: %+v", err, receipt)
+	}
+
+	path, err := WriteReceipt(dir, receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadFile(path)
+	var loaded Receipt
+	if err := json.Unmarshal(data, &loaded); err != nil || loaded.Verify() != nil {
+		t.Fatalf("err: %v | verify: %v | expected the written receipt to verify", err, loaded.Verify())
+	}
+
+	loaded.Username = "Forged"
+	if loaded.Verify() != ErrReceiptSignature {
+		t.Fatal("expected an altered receipt to fail verification")
+	}
+}
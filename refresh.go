@@ -0,0 +1,99 @@
+package mcgo
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+type refreshReqBody struct {
+	AccessToken string `json:"accessToken"`
+	ClientToken string `json:"clientToken"`
+	RequestUser bool   `json:"requestUser"`
+}
+
+// Returns the client token to authenticate with: the stored one, or one derived from the email so it stays the same across runs
+// (mojang keeps bearers issued to other client tokens valid, random tokens would pile them up).
+func (account *MCaccount) clientToken() string {
+	if account.ClientToken != "" {
+		return account.ClientToken
+	}
+	sum := sha1.Sum([]byte("mcgo:" + strings.ToLower(account.Email)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// posts v as json to an authserver.mojang.com endpoint
+func (account *MCaccount) postAuthserver(endpoint string, v interface{}) (*http.Response, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", "https://authserver.mojang.com/"+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return account.do(req)
+}
+
+// Renews the bearer of a Mojang account with the yggdrasil refresh endpoint, using the current Bearer and ClientToken instead of
+// email, password and security questions. The old bearer stops working.
+func (account *MCaccount) Refresh() error {
+	from := account.snapshot()
+	if from.Bearer == "" || from.ClientToken == "" {
+		return errors.New("refresh needs a bearer and the client token it was issued to")
+	}
+
+	resp, err := account.postAuthserver("refresh", refreshReqBody{
+		AccessToken: from.Bearer,
+		ClientToken: from.ClientToken,
+		RequestUser: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 403 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        errors.New("bearer can't be refreshed, authenticate again"),
+		}
+	}
+
+	if resp.StatusCode >= 300 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when refreshing bearer", resp.Status),
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var refreshed authenticateReqResp
+	if err := json.Unmarshal(respBytes, &refreshed); err != nil {
+		return err
+	}
+
+	from.Bearer = refreshed.Accesstoken
+	from.updateExpiry()
+	from.ClientToken = refreshed.Clienttoken
+	if refreshed.User.ID != "" {
+		from.UUID = refreshed.User.ID
+		from.Username = refreshed.User.Username
+	}
+	account.applyAuth(&from)
+	return nil
+}
@@ -0,0 +1,71 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestRefresh(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "refresh@example.com", Password: "pw", Name: "Fresh", OwnsGame: true})
+	acc := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Client: &Client{HTTP: srv.HTTPClient()}}
+	if err := acc.MojangAuthenticate(); err != nil || acc.ClientToken == "" {
+		t.Fatalf("err: %v | client token: %v | expected authenticate to store the client token", err, acc.ClientToken)
+	}
+
+	stable := (&MCaccount{Email: fake.Email}).clientToken()
+	if acc.ClientToken != stable || len(stable) != 32 {
+		t.Fatalf("client token: %v | expected the stable token %v to be sent and stored", acc.ClientToken, stable)
+	}
+
+	old := acc.Bearer
+	if err := acc.Refresh(); err != nil || acc.Bearer == old || acc.Username != "Fresh" {
+		t.Fatalf("err: %v | bearer: %v | expected a new bearer", err, acc.Bearer)
+	}
+	if _, ok := srv.Account(old); ok {
+		t.Fatal("expected the old bearer to stop working")
+	}
+
+	acc.Bearer = old
+	if err := acc.Refresh(); err == nil {
+		t.Fatal("expected refreshing an invalidated bearer to fail")
+	}
+}
+
+func TestRefreshConcurrentReads(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "racing@example.com", Password: "pw", Name: "Racing", OwnsGame: true})
+	acc := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Client: &Client{HTTP: srv.HTTPClient()}}
+	if err := acc.MojangAuthenticate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a background refresh reading the auth fields while they're replaced, for the race detector
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				acc.snapshot()
+			}
+		}
+	}()
+	refreshErr := acc.Refresh()
+	_, validateErr := acc.ValidateBearer()
+	invalidateErr := acc.Invalidate()
+	close(stop)
+	<-done
+
+	if refreshErr != nil || validateErr != nil || invalidateErr != nil || acc.Bearer != "" {
+		t.Fatalf("refresh: %v | validate: %v | invalidate: %v | bearer: %v | expected a refreshed, valid and then forgotten bearer", refreshErr, validateErr, invalidateErr, acc.Bearer)
+	}
+}
@@ -0,0 +1,209 @@
+package mcgo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// outcome of checking one candidate name
+type ScanResult struct {
+	Name      string
+	Status    NameStatus
+	Err       error
+	CheckedAt time.Time
+}
+
+// Checks candidate names for availability as a pipeline: a generator feeding names, the checker (rate limited, one check at a time)
+// and the caller reading results as the sink. Channels between the stages are bounded, so a slow sink stalls the checker and a stalled
+// checker stalls the generator, memory stays flat no matter how many candidates there are.
+type Scanner struct {
+	Account *MCaccount
+	Rate    time.Duration // minimum time between checks
+	Buffer  int           // capacity of the results channel
+	Backoff time.Duration // when > 0, a ratelimited check is retried after waiting Backoff instead of being reported
+
+	mu      sync.Mutex
+	running chan struct{} // closed while the scanner is not paused
+	checked int
+}
+
+func NewScanner(account *MCaccount, rate time.Duration) *Scanner {
+	return &Scanner{Account: account, Rate: rate, Buffer: 64}
+}
+
+// returns the channel that is closed while the scanner runs, creating it (running) on first use
+func (s *Scanner) gate() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running == nil {
+		s.running = make(chan struct{})
+		close(s.running)
+	}
+	return s.running
+}
+
+// Stops the scanner before its next check. Candidates wait in the generator, none are skipped.
+func (s *Scanner) Pause() {
+	s.gate()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.running:
+		s.running = make(chan struct{})
+	default:
+	}
+}
+
+// resumes a paused scanner
+func (s *Scanner) Resume() {
+	s.gate()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.running:
+	default:
+		close(s.running)
+	}
+}
+
+func (s *Scanner) Paused() bool {
+	select {
+	case <-s.gate():
+		return false
+	default:
+		return true
+	}
+}
+
+// number of names checked so far
+func (s *Scanner) Checked() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checked
+}
+
+// Blocks while the scanner is paused and until Rate has passed since last, then sets last to now. Returns false if ctx is done first.
+func (s *Scanner) wait(ctx context.Context, last *time.Time) bool {
+	select {
+	case <-s.gate():
+	case <-ctx.Done():
+		return false
+	}
+	if d := time.Until(last.Add(s.Rate)); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return false
+		}
+	}
+	*last = time.Now()
+	return true
+}
+
+// Checks every name received from names until it's closed or ctx is done, then closes the returned channel.
+func (s *Scanner) Scan(ctx context.Context, names <-chan string) <-chan ScanResult {
+	results := make(chan ScanResult, s.Buffer)
+
+	go func() {
+		defer close(results)
+
+		var last time.Time
+
+		for {
+			var name string
+			var ok bool
+			select {
+			case name, ok = <-names:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			var result ScanResult
+			for {
+				if !s.wait(ctx, &last) {
+					return
+				}
+				status, err := s.Account.NameStatus(name)
+				result = ScanResult{Name: name, Status: status, Err: err, CheckedAt: s.Account.now()}
+				if s.Backoff <= 0 || Categorize(err) != CategoryRateLimit {
+					break
+				}
+				select {
+				case <-time.After(s.Backoff):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			s.mu.Lock()
+			s.checked++
+			s.mu.Unlock()
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results
+}
+
+// Generates candidates lazily from a slice, for feeding Scan.
+func NameSource(ctx context.Context, names []string) <-chan string {
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		for _, name := range names {
+			select {
+			case out <- name:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Generates every name of the given length made of charset characters, in order, without holding them in memory.
+func NameCombinations(ctx context.Context, charset string, length int) <-chan string {
+	out := make(chan string)
+	chars := []rune(charset)
+	go func() {
+		defer close(out)
+		if length <= 0 || len(chars) == 0 {
+			return
+		}
+		indexes := make([]int, length)
+		name := make([]rune, length)
+		for {
+			for i, idx := range indexes {
+				name[i] = chars[idx]
+			}
+			select {
+			case out <- string(name):
+			case <-ctx.Done():
+				return
+			}
+
+			// increment like an odometer, last position fastest
+			i := length - 1
+			for ; i >= 0; i-- {
+				indexes[i]++
+				if indexes[i] < len(chars) {
+					break
+				}
+				indexes[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,78 @@
+package mcgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestNameCombinations(t *testing.T) {
+	var names []string
+	for name := range NameCombinations(context.Background(), "ab", 2) {
+		names = append(names, name)
+	}
+	if len(names) != 4 || names[0] != "aa" || names[1] != "ab" || names[3] != "bb" {
+		t.Fatalf("got %v | expected aa ab ba bb", names)
+	}
+}
+
+func TestScanner(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "scan@example.com", Name: "Taken", OwnsGame: true})
+	srv.BlockName("blocked")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scanner := NewScanner(acc, 0)
+	scanner.Buffer = 0
+	scanner.Pause()
+	results := scanner.Scan(ctx, NameSource(ctx, []string{"Taken", "blocked", "freename"}))
+
+	select {
+	case r := <-results:
+		t.Fatalf("got %+v | expected no results while paused", r)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	scanner.Resume()
+	expected := []NameStatus{NameTaken, NameBlocked, NameAvailable}
+	for i, status := range expected {
+		r := <-results
+		if r.Err != nil || r.Status != status {
+			t.Fatalf("err: %v | result %v: %+v, expected %v", r.Err, i, r, status)
+		}
+	}
+	if _, ok := <-results; ok || scanner.Checked() != 3 {
+		t.Fatalf("checked: %v | expected results to close after 3 names", scanner.Checked())
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "scan@example.com", Name: "Scanner", OwnsGame: true})
+	owner := srv.AddAccount(mcgotest.Account{Email: "og@example.com", Name: "Og", SkinURL: "https://textures.minecraft.net/texture/abc", SkinVariant: "SLIM"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scanner := NewScanner(acc, 0)
+	enriched := map[string]EnrichedResult{}
+	for r := range scanner.Enrich(ctx, scanner.Scan(ctx, NameSource(ctx, []string{"Og", "freename"}))) {
+		enriched[r.Name] = r
+	}
+
+	og := enriched["Og"]
+	if og.EnrichErr != nil || og.Owner == nil || og.Owner.UUID != owner.UUID || og.Owner.SkinURL != owner.SkinURL || og.Owner.SkinVariant != "SLIM" {
+		t.Fatalf("err: %v | owner: %+v | expected the owner's profile", og.EnrichErr, og.Owner)
+	}
+	if free := enriched["freename"]; free.Owner != nil || free.Status != NameAvailable {
+		t.Fatalf("result: %+v | expected available name to pass through", free)
+	}
+}
@@ -0,0 +1,77 @@
+package mcgo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestSecurityQuestions(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "sq@example.com", Password: "pw", Name: "Asked", OwnsGame: true, SecurityQuestions: []mcgotest.SecurityQuestion{
+		{ID: 101, QuestionID: 1, Question: "What is your favorite pet's name?", Answer: "rex"},
+		{ID: 102, QuestionID: 5, Question: "What was your childhood nickname?", Answer: "ace"},
+		{ID: 103, QuestionID: 9, Question: "In what city were you born?", Answer: "oslo"},
+	}})
+
+	questions, err := acc.GetSecurityQuestions()
+	if err != nil || len(questions) != 3 || questions[1].Question.Question != "What was your childhood nickname?" {
+		t.Fatalf("err: %v | questions: %+v | expected the three questions", err, questions)
+	}
+	if needed, err := acc.NeedsSecurityAnswers(); err != nil || !needed {
+		t.Fatalf("err: %v | needed: %v | expected answers to be needed", err, needed)
+	}
+
+	if err := acc.SubmitSecurityAnswers(map[int]string{1: "rex", 5: "ace"}); err == nil {
+		t.Fatal("expected a missing answer to be refused before submitting")
+	}
+	if err := acc.SubmitSecurityAnswers(map[int]string{1: "rex", 5: "ace", 9: "bergen"}); err == nil {
+		t.Fatal("expected a wrong answer to be rejected")
+	}
+	if err := acc.SubmitSecurityAnswers(map[int]string{9: "oslo", 1: "rex", 5: "ace"}); err != nil {
+		t.Fatal(err)
+	}
+	if needed, err := acc.NeedsSecurityAnswers(); err != nil || needed {
+		t.Fatalf("err: %v | needed: %v | expected no more answers to be needed", err, needed)
+	}
+}
+
+func TestAnswersByQuestion(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	questions := []mcgotest.SecurityQuestion{
+		{ID: 201, QuestionID: 3, Question: "What is your favorite movie?", Answer: "alien"},
+		{ID: 202, QuestionID: 7, Question: "What was your first car?", Answer: "golf"},
+		{ID: 203, QuestionID: 2, Question: "What is your favorite color?", Answer: "teal"},
+	}
+	fake := srv.AddAccount(mcgotest.Account{Email: "keyed@example.com", Password: "pw", Name: "Keyed", OwnsGame: true, SecurityQuestions: questions})
+
+	// answers in another order than the questions, which positional SecurityAnswers would pair wrong
+	acc := &MCaccount{Email: fake.Email, Password: fake.Password, Type: Mj, Client: &Client{HTTP: srv.HTTPClient()},
+		AnswersByQuestion: map[int]string{2: "teal", 3: "alien", 7: "golf"}}
+	if err := acc.MojangAuthenticate(); err != nil || !acc.Authenticated {
+		t.Fatalf("err: %v | expected the keyed answers to be paired with their questions", err)
+	}
+
+	acc.AnswersByQuestion = map[int]string{2: "teal", 3: "alien", 7: "golf", 11: "extra"}
+	if err := acc.SubmitSecurityAnswers(acc.AnswersByQuestion); err == nil || !strings.Contains(err.Error(), "[11]") {
+		t.Fatalf("err: %v | expected an answer to a question not asked to be refused", err)
+	}
+	if posts := countPosts(srv.Requests(), "/user/security/location"); posts != 1 {
+		t.Fatalf("posts: %v | expected the refused answers not to be sent", posts)
+	}
+}
+
+func countPosts(requests []mcgotest.Request, path string) int {
+	n := 0
+	for _, r := range requests {
+		if r.Method == "POST" && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
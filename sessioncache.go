@@ -0,0 +1,142 @@
+package mcgo
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// A tls.ClientSessionCache that keeps sessions in a file, so the first snipe connection after a restart can resume
+// the TLS session instead of paying for a full handshake. Only sessions the server issued a ticket for are kept,
+// and the server still decides whether to resume. Saving needs Go 1.21, older versions keep sessions in memory only.
+// Use it with NewTLSDialer, or as TransportOptions.TLSSessionCache, and Close it before exiting.
+// The file holds the secrets needed to resume the sessions: it is written readable by the owner only, keep it as private as the tokens.
+type PersistentSessionCache struct {
+	Path string
+
+	mu       sync.Mutex
+	sessions map[string]*tls.ClientSessionState
+	saved    map[string]savedSession
+	dirty    bool          // saved changed since the file was last written
+	flushed  chan struct{} // closed when the running flush ends, nil if none runs
+	fileMu   sync.Mutex    // one write of the file at a time
+}
+
+type savedSession struct {
+	Ticket []byte `json:"ticket"`
+	State  []byte `json:"state"`
+}
+
+// Returns a cache persisted to path, loading the sessions saved there if it exists. Sessions that can't be restored are dropped.
+func NewPersistentSessionCache(path string) (*PersistentSessionCache, error) {
+	c := &PersistentSessionCache{
+		Path:     path,
+		sessions: map[string]*tls.ClientSessionState{},
+		saved:    map[string]savedSession{},
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.saved); err != nil {
+		return nil, err
+	}
+
+	for key, saved := range c.saved {
+		session, err := restoreSession(saved)
+		if err != nil {
+			delete(c.saved, key)
+			continue
+		}
+		c.sessions[key] = session
+	}
+	return c, nil
+}
+
+func (c *PersistentSessionCache) Get(sessionKey string) (*tls.ClientSessionState, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	session, ok := c.sessions[sessionKey]
+	return session, ok
+}
+
+// Stores the session, a nil session removes the key. The file is written in the background, so handshakes aren't held up by the disk.
+// Errors saving are ignored, the cache keeps working from memory.
+func (c *PersistentSessionCache) Put(sessionKey string, cs *tls.ClientSessionState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cs == nil {
+		delete(c.sessions, sessionKey)
+		delete(c.saved, sessionKey)
+	} else {
+		c.sessions[sessionKey] = cs
+		if saved, err := saveSession(cs); err == nil {
+			c.saved[sessionKey] = saved
+		}
+	}
+
+	c.dirty = true
+	if c.Path != "" && c.flushed == nil {
+		c.flushed = make(chan struct{})
+		go c.flush(c.flushed)
+	}
+}
+
+// writes the file until no Put changed the sessions during the last write
+func (c *PersistentSessionCache) flush(done chan struct{}) {
+	defer close(done)
+	for {
+		c.mu.Lock()
+		if !c.dirty {
+			c.flushed = nil
+			c.mu.Unlock()
+			return
+		}
+		c.dirty = false
+		data, err := json.Marshal(c.saved)
+		c.mu.Unlock()
+
+		if err == nil {
+			c.write(data)
+		}
+	}
+}
+
+// Waits for the background write and saves the sessions once more, returning the error the background writes ignore.
+func (c *PersistentSessionCache) Close() error {
+	c.mu.Lock()
+	done := c.flushed
+	c.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+
+	if c.Path == "" {
+		return nil
+	}
+	c.mu.Lock()
+	c.dirty = false
+	data, err := json.Marshal(c.saved)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.write(data)
+}
+
+// Writes to a temporary file first, so a crash never leaves a corrupt file.
+func (c *PersistentSessionCache) write(data []byte) error {
+	c.fileMu.Lock()
+	defer c.fileMu.Unlock()
+	if err := ioutil.WriteFile(c.Path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(c.Path+".tmp", c.Path)
+}
@@ -0,0 +1,26 @@
+//go:build go1.21
+// +build go1.21
+
+package mcgo
+
+import "crypto/tls"
+
+func saveSession(cs *tls.ClientSessionState) (savedSession, error) {
+	ticket, state, err := cs.ResumptionState()
+	if err != nil {
+		return savedSession{}, err
+	}
+	stateBytes, err := state.Bytes()
+	if err != nil {
+		return savedSession{}, err
+	}
+	return savedSession{Ticket: ticket, State: stateBytes}, nil
+}
+
+func restoreSession(saved savedSession) (*tls.ClientSessionState, error) {
+	state, err := tls.ParseSessionState(saved.State)
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewResumptionState(saved.Ticket, state)
+}
@@ -0,0 +1,20 @@
+//go:build !go1.21
+// +build !go1.21
+
+package mcgo
+
+import (
+	"crypto/tls"
+	"errors"
+)
+
+// sessions can only be serialized since Go 1.21
+var errSessionsNotSerializable = errors.New("tls sessions can't be saved before go 1.21")
+
+func saveSession(cs *tls.ClientSessionState) (savedSession, error) {
+	return savedSession{}, errSessionsNotSerializable
+}
+
+func restoreSession(saved savedSession) (*tls.ClientSessionState, error) {
+	return nil, errSessionsNotSerializable
+}
@@ -0,0 +1,54 @@
+//go:build go1.21
+// +build go1.21
+
+package mcgo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestPersistentSessionCache(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	path := filepath.Join(t.TempDir(), "sessions.json")
+
+	// tls 1.3 tickets arrive after the handshake, so a request is made to read them
+	connect := func(cache tls.ClientSessionCache) bool {
+		conn, err := tls.Dial("tcp", srv.Listener.Addr().String(), &tls.Config{RootCAs: roots, ServerName: "example.com", ClientSessionCache: cache})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		conn.Write([]byte("GET / HTTP/1.1\r\nHost: example.com\r\nConnection: close\r\n\r\n"))
+		ioutil.ReadAll(conn)
+		return conn.ConnectionState().DidResume
+	}
+
+	cache, err := NewPersistentSessionCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if connect(cache) {
+		t.Fatal("expected a full handshake without a saved session")
+	}
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := NewPersistentSessionCache(path)
+	if err != nil || len(restarted.sessions) != 1 {
+		t.Fatalf("err: %v | sessions: %v | expected the session to be loaded from the file", err, len(restarted.sessions))
+	}
+	if !connect(restarted) {
+		t.Fatal("expected the loaded session to be resumed")
+	}
+}
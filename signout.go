@@ -0,0 +1,63 @@
+package mcgo
+
+import (
+	"fmt"
+	"time"
+)
+
+type signoutReqBody struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// clears the token fields after they were revoked
+func (account *MCaccount) forgetBearer() {
+	lock := account.authLock()
+	lock.Lock()
+	defer lock.Unlock()
+	account.Bearer = ""
+	account.ExpiresAt = time.Time{}
+	account.ClientToken = ""
+	account.Authenticated = false
+}
+
+// Revokes the account's bearer with the yggdrasil invalidate endpoint, so it can't be used after the tool is done with the account.
+func (account *MCaccount) Invalidate() error {
+	auth := account.snapshot()
+	resp, err := account.postAuthserver("invalidate", validateReqBody{AccessToken: auth.Bearer, ClientToken: auth.ClientToken})
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when invalidating bearer", resp.Status),
+		}
+	}
+
+	account.forgetBearer()
+	return nil
+}
+
+// Revokes every bearer of the account (not just the current one) with the yggdrasil signout endpoint, using email and password.
+func (account *MCaccount) Signout() error {
+	resp, err := account.postAuthserver("signout", signoutReqBody{Username: account.Email, Password: account.Password})
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when signing out", resp.Status),
+		}
+	}
+
+	account.forgetBearer()
+	return nil
+}
@@ -0,0 +1,37 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestInvalidateAndSignout(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "revoke@example.com", Password: "pw", Name: "Revoked", OwnsGame: true})
+	bearer := acc.Bearer
+	if err := acc.Invalidate(); err != nil || acc.Bearer != "" || acc.Authenticated {
+		t.Fatalf("err: %v | bearer: %v | expected the bearer to be cleared", err, acc.Bearer)
+	}
+	if _, ok := srv.Account(bearer); ok {
+		t.Fatal("expected the invalidated bearer to stop working")
+	}
+
+	if err := acc.MojangAuthenticate(); err != nil {
+		t.Fatal(err)
+	}
+	bearer = acc.Bearer
+	if err := acc.Signout(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.Account(bearer); ok {
+		t.Fatal("expected signout to revoke the bearer")
+	}
+
+	acc.Password = "wrong"
+	if err := acc.Signout(); err == nil {
+		t.Fatal("expected signout with a wrong password to fail")
+	}
+}
@@ -0,0 +1,91 @@
+package mcgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Skin variants, the arm model a skin is drawn on.
+const (
+	SkinClassic = "CLASSIC" // Steve, 4 pixel arms
+	SkinSlim    = "SLIM"    // Alex, 3 pixel arms
+)
+
+// variant of the active skin, SkinClassic or SkinSlim, empty if the profile has no active skin
+func (profile Profile) Variant() string {
+	skin, ok := profile.ActiveSkin()
+	if !ok {
+		return ""
+	}
+	return skin.Variant
+}
+
+type SkinOptions struct {
+	Variant         string // SkinClassic if empty
+	PreserveVariant bool   // use the variant of the current skin instead, Variant only if the profile has none
+}
+
+type changeSkinBody struct {
+	Variant string `json:"variant"`
+	URL     string `json:"url"`
+}
+
+// Changes the skin to the one at skinURL and returns the updated profile. With PreserveVariant the profile is fetched first,
+// so batch changes don't switch the arm model of accounts.
+func (account *MCaccount) ChangeSkin(skinURL string, opts SkinOptions) (Profile, error) {
+	if err := account.checkWritable(); err != nil {
+		return Profile{}, err
+	}
+	variant := opts.Variant
+	if opts.PreserveVariant {
+		profile, err := account.FetchProfile()
+		if err != nil {
+			return Profile{}, err
+		}
+		if current := profile.Variant(); current != "" {
+			variant = current
+		}
+	}
+	if variant == "" {
+		variant = SkinClassic
+	}
+
+	body, err := json.Marshal(changeSkinBody{Variant: variant, URL: skinURL})
+	if err != nil {
+		return Profile{}, err
+	}
+
+	req, err := account.AuthenticatedReq("POST", "https://api.minecraftservices.com/minecraft/profile/skins", bytes.NewReader(body))
+	if err != nil {
+		return Profile{}, err
+	}
+
+	resp, err := account.do(req)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return Profile{}, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when changing skin", resp.Status),
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var profile Profile
+	if err := json.Unmarshal(respBytes, &profile); err != nil {
+		return Profile{}, err
+	}
+	account.recordSkin(profile, account.now())
+	account.recordOwnProfile(profile)
+	return profile, nil
+}
@@ -0,0 +1,27 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestChangeSkin(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{Name: "Alex", OwnsGame: true, SkinURL: "http://textures.minecraft.net/texture/old", SkinVariant: SkinSlim})
+
+	profile, err := acc.ChangeSkin("http://textures.minecraft.net/texture/new", SkinOptions{PreserveVariant: true})
+	if err != nil || profile.Variant() != SkinSlim {
+		t.Fatalf("err: %v | variant: %v | expected the slim variant to be kept", err, profile.Variant())
+	}
+
+	profile, err = acc.ChangeSkin("http://textures.minecraft.net/texture/newer", SkinOptions{})
+	if err != nil || profile.Variant() != SkinClassic {
+		t.Fatalf("err: %v | variant: %v | expected classic without PreserveVariant", err, profile.Variant())
+	}
+	if skin, _ := profile.ActiveSkin(); skin.Hash() != "newer" {
+		t.Fatalf("skin: %+v | expected the new skin", skin)
+	}
+}
@@ -0,0 +1,315 @@
+package mcgo
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How the requests of a burst are spread around the drop time.
+type StaggerStrategy string
+
+const (
+	StaggerUniform     StaggerStrategy = "uniform"      // evenly spaced over the spread
+	StaggerFrontLoaded StaggerStrategy = "front-loaded" // densest at the start of the spread, thinning out towards the end
+	StaggerGaussian    StaggerStrategy = "gaussian"     // normally distributed around the drop time, spread/4 standard deviation
+	StaggerAdaptive    StaggerStrategy = "adaptive"     // uniform, shifted earlier by the one way latency measured on a probe connection
+)
+
+type BurstOptions struct {
+	Requests int
+	Spread   time.Duration // width of the window the requests are sent in, centered on the drop time
+	Offset   time.Duration // moves the window, negative sends earlier
+	Strategy StaggerStrategy
+	Pipeline bool // send every request back to back on one connection at the center of the window (HTTP/1.1 pipelining) instead of spreading them
+
+	ExcludeOutliers bool    // open every connection 20 seconds before the window, time a round trip on it, and replace those with a much slower handshake or round trip
+	OutlierFactor   float64 // handshakes and round trips this many times the median count as outliers, 2 if 0
+
+	// Latency budget: 30 seconds before the drop the latency is calibrated, and if it's over MaxLatency or the jitter over MaxJitter
+	// nothing is sent (a doomed attempt would still use up the name change) unless ConfirmLatency returns true. 0 doesn't limit.
+	MaxLatency     time.Duration
+	MaxJitter      time.Duration
+	ConfirmLatency func(Calibration) bool `json:"-"`
+
+	// Experimental: host:port of an HTTP/3 endpoint for api.minecraftservices.com (e.g. a front or proxy offering it) whose QUIC handshakes
+	// are timed during the calibration, see Calibration.HTTP3. It's only measured for comparison, "" doesn't probe. Needs the quic build tag.
+	HTTP3Probe string
+
+	// Seeds the random send times of StaggerGaussian, any value including 0. Burst picks one if nil and records it in the result's Options,
+	// so passing those options again reproduces the schedule.
+	Seed *int64
+}
+
+// Result of a burst, Options are the ones used (for adaptive bursts, including the measured offset). Schedule holds the planned send time of each request, Results and Errors are in the same order.
+type BurstResult struct {
+	Username string
+	DropTime time.Time
+	Options  BurstOptions
+	Schedule []time.Time
+	Results  []NameChangeReturn
+	Errors   []error
+	Replaced int // connections replaced as outliers, with ExcludeOutliers
+
+	Calibration Calibration // measured before adaptive bursts, bursts with a latency budget and bursts with an HTTP3Probe
+}
+
+// returns true if any request of the burst changed the name
+func (r BurstResult) Succeeded() bool {
+	for _, result := range r.Results {
+		if result.ChangedName {
+			return true
+		}
+	}
+	return false
+}
+
+// index of the request that changed the name, -1 if none did. For pipelined bursts it's the position in the pipeline.
+func (r BurstResult) Position() int {
+	for i, result := range r.Results {
+		if result.ChangedName {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the send time of each request for the given strategy, sorted. Adaptive is treated as uniform, see Burst.
+func StaggerSchedule(dropTime time.Time, opts BurstOptions) []time.Time {
+	n := opts.Requests
+	center := dropTime.Add(opts.Offset)
+	if n <= 0 {
+		return nil
+	}
+	if n == 1 {
+		return []time.Time{center}
+	}
+
+	start := center.Add(-opts.Spread / 2)
+
+	// position of request i in the window, between 0 and 1
+	position := func(i int) float64 {
+		return float64(i) / float64(n-1)
+	}
+
+	normal := rand.NormFloat64
+	if opts.Seed != nil {
+		normal = rand.New(rand.NewSource(*opts.Seed)).NormFloat64
+	}
+
+	schedule := make([]time.Time, n)
+	for i := range schedule {
+		switch opts.Strategy {
+		case StaggerFrontLoaded:
+			p := position(i)
+			schedule[i] = start.Add(time.Duration(p * p * float64(opts.Spread)))
+		case StaggerGaussian:
+			schedule[i] = center.Add(time.Duration(normal() * float64(opts.Spread) / 4))
+		default:
+			schedule[i] = start.Add(time.Duration(position(i) * float64(opts.Spread)))
+		}
+	}
+
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].Before(schedule[j]) })
+	return schedule
+}
+
+// Sends opts.Requests name changes spread around dropTime according to opts.Strategy, each on its own connection.
+// With StaggerAdaptive a probe connection is opened first and the window moved earlier by the measured latency.
+// With ExcludeOutliers the connections are opened together ahead of the window, and with a latency budget
+// a burst over it isn't sent, see BurstOptions.
+func (account *MCaccount) Burst(username string, dropTime time.Time, createProfile bool, opts BurstOptions) BurstResult {
+	var calibration Calibration
+	if opts.Strategy == StaggerAdaptive || opts.hasLatencyBudget() || opts.HTTP3Probe != "" {
+		time.Sleep(time.Until(dropTime) - time.Second*30)
+		probes := 1
+		if opts.hasLatencyBudget() || opts.HTTP3Probe != "" {
+			probes = calibrationProbes
+		}
+		var err error
+		calibration, err = account.calibrate(probes)
+		if opts.HTTP3Probe != "" {
+			calibration.HTTP3 = calibrateHTTP3(opts.HTTP3Probe, probes)
+		}
+		if opts.hasLatencyBudget() {
+			if err == nil && !opts.withinLatencyBudget(calibration) && (opts.ConfirmLatency == nil || !opts.ConfirmLatency(calibration)) {
+				err = &LatencyBudgetError{Calibration: calibration, MaxLatency: opts.MaxLatency, MaxJitter: opts.MaxJitter}
+			}
+			if err != nil {
+				return abortedBurst(username, dropTime, opts, calibration, err)
+			}
+		}
+		if err == nil && opts.Strategy == StaggerAdaptive {
+			opts.Offset -= calibration.Latency
+		}
+	}
+
+	if opts.Seed == nil && opts.Strategy == StaggerGaussian {
+		seed := time.Now().UnixNano()
+		opts.Seed = &seed
+	}
+
+	result := BurstResult{
+		Username:    username,
+		DropTime:    dropTime,
+		Options:     opts,
+		Calibration: calibration,
+	}
+
+	if opts.Pipeline {
+		account.pipelineBurst(&result, createProfile)
+		return result
+	}
+
+	result.Schedule = StaggerSchedule(dropTime, opts)
+	result.Results = make([]NameChangeReturn, len(result.Schedule))
+	result.Errors = make([]error, len(result.Schedule))
+
+	var warm []warmConn
+	if opts.ExcludeOutliers && len(result.Schedule) > 0 {
+		time.Sleep(time.Until(result.Schedule[0]) - time.Second*20)
+		factor := opts.OutlierFactor
+		if factor <= 0 {
+			factor = 2
+		}
+		warm, result.Replaced = account.prewarm(len(result.Schedule), factor)
+	}
+
+	cooldown := &cooldownCheck{}
+	var wg sync.WaitGroup
+	for i, sendTime := range result.Schedule {
+		wg.Add(1)
+		go func(i int, sendTime time.Time) {
+			defer wg.Done()
+			if warm == nil {
+				result.Results[i], result.Errors[i] = account.changeNameVia(account.dialer(), username, sendTime, createProfile, cooldown)
+				recordResult(result.Results[i])
+				return
+			}
+
+			if warm[i].err != nil {
+				result.Results[i], result.Errors[i] = NameChangeReturn{Username: username}, warm[i].err
+				return
+			}
+			defer warm[i].conn.Close()
+			result.Results[i], result.Errors[i] = account.changeNameVia(DialerFromConn(warm[i].conn), username, sendTime, createProfile, cooldown)
+			result.Results[i].DialStart, result.Results[i].DialEnd = warm[i].dialStart, warm[i].dialEnd
+			recordResult(result.Results[i])
+		}(i, sendTime)
+	}
+	wg.Wait()
+
+	if result.Succeeded() {
+		account.recordOwnName(username)
+	}
+
+	return result
+}
+
+// result of a burst that sent nothing, err is returned for every request
+func abortedBurst(username string, dropTime time.Time, opts BurstOptions, calibration Calibration, err error) BurstResult {
+	result := BurstResult{Username: username, DropTime: dropTime, Options: opts, Calibration: calibration}
+	if opts.Requests <= 0 {
+		return result
+	}
+	result.Results = make([]NameChangeReturn, opts.Requests)
+	result.Errors = make([]error, opts.Requests)
+	for i := range result.Results {
+		result.Results[i].Username = username
+		result.Errors[i] = err
+	}
+	return result
+}
+
+// Sends the requests of a pipelined burst on one connection: all but the last bytes of the first request are written early (like changeName),
+// the rest of it and every other request are written in one go at the center of the window. Responses come back in request order.
+func (account *MCaccount) pipelineBurst(result *BurstResult, createProfile bool) {
+	n := result.Options.Requests
+	if n <= 0 {
+		return
+	}
+	sendAt := result.DropTime.Add(result.Options.Offset)
+
+	result.Schedule = make([]time.Time, n)
+	for i := range result.Schedule {
+		result.Schedule[i] = sendAt
+	}
+	result.Results = make([]NameChangeReturn, n)
+	result.Errors = make([]error, n)
+	for i := range result.Results {
+		result.Results[i].Username = result.Username
+	}
+
+	fail := func(err error) {
+		for i := range result.Errors {
+			result.Errors[i] = err
+		}
+	}
+
+	if err := account.checkWritable(); err != nil {
+		fail(err)
+		return
+	}
+	if err := CheckName(result.Username); err != nil {
+		fail(err)
+		return
+	}
+
+	time.Sleep(time.Until(sendAt) - time.Second*20)
+
+	payload := namePayload(result.Username, account.currentBearer(), createProfile)
+	for i := 0; i < n; i++ {
+		if err := account.spendBudget(); err != nil {
+			fail(err)
+			return
+		}
+	}
+
+	ret := NameChangeReturn{Username: result.Username}
+	ret.DialStart = account.now()
+	conn, err := account.dialer().Dial("tcp", "api.minecraftservices.com:443")
+	ret.DialEnd = account.now()
+	if err != nil {
+		fail(err)
+		return
+	}
+	defer conn.Close()
+
+	conn.Write([]byte(payload[:len(payload)-2]))
+	ret.PartialWriteTime = account.now()
+
+	time.Sleep(time.Until(sendAt))
+	ret.ScheduledTime = sendAt
+	ret.WakeTime = account.now()
+
+	conn.Write([]byte(payload[len(payload)-2:] + strings.Repeat(payload, n-1)))
+	ret.SendTime = account.now()
+
+	statuses, heads, recvTime, readErr := readStatuses(conn, make([]byte, 4096*n), n, account.now)
+	ret.ReceiveTime = recvTime
+
+	cooldown := &cooldownCheck{}
+	for i := range result.Results {
+		r := ret
+		if i < len(statuses) {
+			r.Account = account.snapshot()
+			r.StatusCode = statuses[i]
+			r.ChangedName = statuses[i] < 300
+			r.ResponseHeadHash = heads[i]
+			result.Errors[i] = cooldown.notAllowed(account, statuses[i], createProfile)
+		} else if readErr != nil {
+			result.Errors[i] = readErr
+		} else {
+			result.Errors[i] = fmt.Errorf("no response for pipeline position %v", i)
+		}
+		result.Results[i] = r
+		recordResult(r)
+	}
+
+	if result.Succeeded() {
+		account.recordOwnName(result.Username)
+	}
+}
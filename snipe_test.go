@@ -0,0 +1,303 @@
+package mcgo
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestStaggerSchedule(t *testing.T) {
+	drop := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	uniform := StaggerSchedule(drop, BurstOptions{Requests: 3, Spread: time.Second, Strategy: StaggerUniform})
+	expected := []time.Time{drop.Add(-time.Millisecond * 500), drop, drop.Add(time.Millisecond * 500)}
+	for i := range expected {
+		if !uniform[i].Equal(expected[i]) {
+			t.Fatalf("uniform schedule: %v | expected: %v", uniform, expected)
+		}
+	}
+
+	front := StaggerSchedule(drop, BurstOptions{Requests: 5, Spread: time.Second, Strategy: StaggerFrontLoaded})
+	if front[1].Sub(front[0]) >= front[4].Sub(front[3]) {
+		t.Fatalf("front-loaded schedule: %v | expected gaps to grow", front)
+	}
+
+	gaussian := StaggerSchedule(drop, BurstOptions{Requests: 50, Spread: time.Second, Offset: time.Second, Strategy: StaggerGaussian})
+	for i := 1; i < len(gaussian); i++ {
+		if gaussian[i].Before(gaussian[i-1]) {
+			t.Fatal("expected gaussian schedule to be sorted")
+		}
+	}
+	if mid := gaussian[25]; mid.Before(drop.Add(time.Millisecond*800)) || mid.After(drop.Add(time.Millisecond*1200)) {
+		t.Fatalf("gaussian median: %v | expected near drop + offset", mid)
+	}
+
+	if single := StaggerSchedule(drop, BurstOptions{Requests: 1, Spread: time.Second, Strategy: StaggerFrontLoaded}); !single[0].Equal(drop) {
+		t.Fatalf("single request schedule: %v | expected drop time", single)
+	}
+
+	seed, otherSeed, zero := int64(42), int64(43), int64(0)
+	seeded := BurstOptions{Requests: 5, Spread: time.Second, Strategy: StaggerGaussian, Seed: &seed}
+	if a, b := StaggerSchedule(drop, seeded), StaggerSchedule(drop, seeded); !reflect.DeepEqual(a, b) {
+		t.Fatalf("schedules: %v, %v | expected the same seed to give the same schedule", a, b)
+	}
+	other := seeded
+	other.Seed = &otherSeed
+	if a, b := StaggerSchedule(drop, seeded), StaggerSchedule(drop, other); reflect.DeepEqual(a, b) {
+		t.Fatalf("schedule: %v | expected another seed to give another schedule", a)
+	}
+	zeroSeeded := seeded
+	zeroSeeded.Seed = &zero
+	if a, b := StaggerSchedule(drop, zeroSeeded), StaggerSchedule(drop, zeroSeeded); !reflect.DeepEqual(a, b) {
+		t.Fatalf("schedules: %v, %v | expected seed 0 to be reproducible too", a, b)
+	}
+}
+
+func TestBurstSeed(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	dropTime := time.Now().Add(time.Millisecond * 100)
+	result := acc.Burst("Seeded", dropTime, true, BurstOptions{Requests: 3, Spread: time.Millisecond * 20, Strategy: StaggerGaussian})
+	if result.Options.Seed == nil {
+		t.Fatal("expected the burst to record the seed it used")
+	}
+	if replay := StaggerSchedule(dropTime, result.Options); !reflect.DeepEqual(replay, result.Schedule) {
+		t.Fatalf("replay: %v | schedule: %v | expected the recorded options to reproduce the schedule", replay, result.Schedule)
+	}
+
+	zero := int64(0)
+	result = acc.Burst("Zero", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 2, Spread: time.Millisecond * 20, Strategy: StaggerGaussian, Seed: &zero})
+	if result.Options.Seed == nil || *result.Options.Seed != 0 {
+		t.Fatalf("seed: %v | expected a seed of 0 to be kept", result.Options.Seed)
+	}
+}
+
+func TestBurst(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	result := acc.Burst("Sniped", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 3, Spread: time.Millisecond * 50, Strategy: StaggerUniform})
+
+	created := 0
+	for i, r := range result.Results {
+		if result.Errors[i] != nil {
+			t.Fatal(result.Errors[i])
+		}
+		if r.ChangedName {
+			created++
+		}
+	}
+	if created != 1 || !result.Succeeded() || acc.Username != "Sniped" {
+		t.Fatalf("created: %v | username: %v | expected exactly one successful create", created, acc.Username)
+	}
+}
+
+func TestPipelineBurst(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	result := acc.Burst("Piped", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 3, Pipeline: true})
+
+	for i, err := range result.Errors {
+		if err != nil {
+			t.Fatalf("err: %v | position %v", err, i)
+		}
+	}
+	if result.Position() != 0 || result.Results[1].StatusCode != 400 || acc.Username != "Piped" {
+		t.Fatalf("position: %v | results: %+v | expected the first pipelined request to create the profile", result.Position(), result.Results)
+	}
+	if hash := result.Results[0].ResponseHeadHash; hash == "" || hash == result.Results[1].ResponseHeadHash {
+		t.Fatalf("results: %+v | expected each pipelined response head to be hashed", result.Results)
+	}
+}
+
+func TestPipelineBurstCooldown(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	created := time.Now().Add(-time.Hour * 24)
+	acc := newFakeAccount(srv, mcgotest.Account{Name: "NewProfile", OwnsGame: true, CreatedAt: created, ChangedAt: created})
+	result := acc.Burst("Renamed", time.Now().Add(time.Millisecond*100), false, BurstOptions{Requests: 3, Pipeline: true})
+
+	for i, err := range result.Errors {
+		var cooldownErr *NameChangeNotAllowedError
+		if !errors.As(err, &cooldownErr) || result.Results[i].StatusCode != 403 {
+			t.Fatalf("err: %v | result: %+v | expected position %v to report the cooldown", err, result.Results[i], i)
+		}
+	}
+	lookups := 0
+	for _, req := range srv.Requests() {
+		if req.Path == "/minecraft/profile/namechange" {
+			lookups++
+		}
+	}
+	if lookups != 1 {
+		t.Fatalf("lookups: %v | expected the cooldown to be looked up once", lookups)
+	}
+}
+
+func TestNameChangeTimings(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	changeTime := time.Now().Add(time.Millisecond * 100)
+	ret, err := acc.ChangeName("Timed", changeTime, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ret.ScheduledTime.Equal(changeTime) || ret.WakeTime.Before(changeTime) || ret.SendTime.Before(ret.WakeTime) {
+		t.Fatalf("scheduled: %v | wake: %v | send: %v | expected them in order", ret.ScheduledTime, ret.WakeTime, ret.SendTime)
+	}
+	if slip := ret.SchedulerSlip(); slip < 0 || slip > time.Second {
+		t.Fatalf("slip: %v | expected a small positive slip", slip)
+	}
+	if ret.QueueDelay() != ret.SendTime.Sub(ret.WakeTime) {
+		t.Fatalf("queue delay: %v | expected the time the final write took", ret.QueueDelay())
+	}
+
+	result := acc.Burst("Piped", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 2, Pipeline: true})
+	if r := result.Results[1]; !r.ScheduledTime.Equal(result.Schedule[1]) || r.WakeTime.IsZero() {
+		t.Fatalf("result: %+v | expected pipelined results to share the timings", r)
+	}
+
+	if (NameChangeReturn{}).SchedulerSlip() != 0 || (NameChangeReturn{}).QueueDelay() != 0 {
+		t.Fatal("expected results that never sent to have no timings")
+	}
+}
+
+// delays the handshake of the dials listed in slow
+type slowDialer struct {
+	Dialer
+	mu    sync.Mutex
+	dials int
+	slow  map[int]bool
+}
+
+func (d *slowDialer) Dial(network, addr string) (net.Conn, error) {
+	d.mu.Lock()
+	d.dials++
+	slow := d.slow[d.dials]
+	d.mu.Unlock()
+	if slow {
+		time.Sleep(time.Millisecond * 300)
+	}
+	return d.Dialer.Dial(network, addr)
+}
+
+func TestBurstExcludeOutliers(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	dialer := &slowDialer{Dialer: srv, slow: map[int]bool{2: true}}
+	acc.Dialer = dialer
+
+	result := acc.Burst("Smooth", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 4, Spread: time.Millisecond * 50, ExcludeOutliers: true})
+	if result.Replaced != 1 || dialer.dials != 5 {
+		t.Fatalf("replaced: %v | dials: %v | expected the slow connection to be replaced once", result.Replaced, dialer.dials)
+	}
+	for i, r := range result.Results {
+		if result.Errors[i] != nil {
+			t.Fatal(result.Errors[i])
+		}
+		if r.DialEnd.Sub(r.DialStart) > time.Millisecond*300 {
+			t.Fatalf("result: %+v | expected no slow connection to be used", r)
+		}
+	}
+	if !result.Succeeded() {
+		t.Fatal("expected the burst to create the profile")
+	}
+}
+
+// delays the first response read on the connections of the dials listed in slow
+type laggyDialer struct {
+	Dialer
+	mu    sync.Mutex
+	dials int
+	slow  map[int]bool
+}
+
+type laggyConn struct {
+	net.Conn
+	lagged bool
+}
+
+func (c *laggyConn) Read(p []byte) (int, error) {
+	if !c.lagged {
+		c.lagged = true
+		time.Sleep(time.Millisecond * 300)
+	}
+	return c.Conn.Read(p)
+}
+
+func (d *laggyDialer) Dial(network, addr string) (net.Conn, error) {
+	d.mu.Lock()
+	d.dials++
+	slow := d.slow[d.dials]
+	d.mu.Unlock()
+	conn, err := d.Dialer.Dial(network, addr)
+	if err != nil || !slow {
+		return conn, err
+	}
+	return &laggyConn{Conn: conn}, nil
+}
+
+func TestBurstExcludeSlowRoundTrips(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	dialer := &laggyDialer{Dialer: srv, slow: map[int]bool{3: true}}
+	acc.Dialer = dialer
+
+	result := acc.Burst("Prompt", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 4, Spread: time.Millisecond * 50, ExcludeOutliers: true})
+	if result.Replaced != 1 || dialer.dials != 5 {
+		t.Fatalf("replaced: %v | dials: %v | expected the connection with the slow round trip to be replaced", result.Replaced, dialer.dials)
+	}
+	for _, err := range result.Errors {
+		if err != nil {
+			t.Fatalf("err: %v | expected the probed connections to still take the payload", err)
+		}
+	}
+	if !result.Succeeded() {
+		t.Fatal("expected the burst to create the profile")
+	}
+}
+
+func TestBurstLatencyBudget(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	acc := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	// the fourth probe takes 300ms, a jitter of about 75ms one way
+	acc.Dialer = &slowDialer{Dialer: srv, slow: map[int]bool{4: true}}
+
+	result := acc.Burst("Doomed", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 2, MaxJitter: time.Millisecond * 20})
+	var budgetErr *LatencyBudgetError
+	if !errors.As(result.Errors[0], &budgetErr) || result.Calibration.Samples != calibrationProbes || result.Succeeded() {
+		t.Fatalf("errors: %v | calibration: %+v | expected the burst to be aborted", result.Errors, result.Calibration)
+	}
+	if _, err := acc.FetchProfile(); err == nil {
+		t.Fatal("expected no profile to be created by an aborted burst")
+	}
+
+	asked := false
+	acc.Dialer = &slowDialer{Dialer: srv, slow: map[int]bool{4: true}}
+	result = acc.Burst("Doomed", time.Now().Add(time.Millisecond*100), true, BurstOptions{Requests: 1, MaxJitter: time.Millisecond * 20, ConfirmLatency: func(c Calibration) bool {
+		asked = true
+		return true
+	}})
+	if !asked || result.Errors[0] != nil || !result.Succeeded() {
+		t.Fatalf("asked: %v | errors: %v | expected a confirmed burst to be sent", asked, result.Errors)
+	}
+}
@@ -0,0 +1,137 @@
+package mcgo
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+var statusLinePrefix = []byte("HTTP/1.")
+
+// How long readStatus keeps reading after the first bytes when they don't hold a final status line yet.
+var StatusReadTimeout = time.Second * 5
+
+// Returned when a raw name change response has no status line. Raw holds (up to) the first 512 bytes received.
+type StatusParseError struct {
+	Raw []byte
+}
+
+func (e *StatusParseError) Error() string {
+	return fmt.Sprintf("no status code found in response: %q", e.Raw)
+}
+
+// Finds the final status code in a raw HTTP/1.x response: the first status line wherever it starts (bytes before it are skipped),
+// passing over 1xx interim responses. ok is false if raw doesn't hold a final status line (yet).
+func parseStatusCode(raw []byte) (status int, ok bool) {
+	status, _, ok = nextStatus(raw)
+	return status, ok
+}
+
+// like parseStatusCode, also returning raw from the start of the final status line on
+func nextStatus(raw []byte) (status int, line []byte, ok bool) {
+	rest := raw
+	for {
+		i := bytes.Index(rest, statusLinePrefix)
+		if i < 0 {
+			return 0, nil, false
+		}
+		line := rest[i:]
+		// "HTTP/1.1 200" is the shortest line with a full status code
+		if len(line) < 12 {
+			return 0, nil, false
+		}
+		code, err := strconv.Atoi(string(line[9:12]))
+		if err != nil || line[8] != ' ' {
+			rest = line[len(statusLinePrefix):]
+			continue
+		}
+		if code >= 100 && code < 200 {
+			rest = line[12:]
+			continue
+		}
+		return code, line, true
+	}
+}
+
+// returns the status line and headers of the final response in raw, nil if raw has no final status line
+func responseHead(raw []byte) []byte {
+	_, line, ok := nextStatus(raw)
+	if !ok {
+		return nil
+	}
+	if end := bytes.Index(line, []byte("\r\n\r\n")); end >= 0 {
+		return line[:end+4]
+	}
+	return bytes.TrimRight(line, "\x00")
+}
+
+// final status codes of the pipelined responses in raw, in order, and raw from each of their status lines on
+func parseStatusCodes(raw []byte) ([]int, [][]byte) {
+	var statuses []int
+	var lines [][]byte
+	for {
+		status, line, ok := nextStatus(raw)
+		if !ok {
+			return statuses, lines
+		}
+		statuses = append(statuses, status)
+		lines = append(lines, line)
+		raw = line[12:]
+	}
+}
+
+// Reads from conn into buf until it holds a final status code, returning it and the time the first bytes arrived.
+// Reads after the first give up after StatusReadTimeout, a StatusParseError is returned if no status was found by then.
+func readStatus(conn net.Conn, buf []byte, now func() time.Time) (int, time.Time, error) {
+	n, err := conn.Read(buf)
+	firstByte := now()
+
+	for {
+		if status, ok := parseStatusCode(buf[:n]); ok {
+			return status, firstByte, nil
+		}
+		if err != nil || n == len(buf) {
+			break
+		}
+		conn.SetReadDeadline(time.Now().Add(StatusReadTimeout))
+		var m int
+		m, err = conn.Read(buf[n:])
+		n += m
+	}
+
+	raw := buf[:n]
+	if len(raw) > 512 {
+		raw = raw[:512]
+	}
+	return 0, firstByte, &StatusParseError{Raw: append([]byte(nil), raw...)}
+}
+
+// Reads from conn into buf until it holds the final status codes of n pipelined responses, the connection ends or StatusReadTimeout passes
+// without them. Returns the statuses found (possibly fewer than n), the hashes of their heads (see Receipt) and the time the first bytes arrived.
+func readStatuses(conn net.Conn, buf []byte, n int, now func() time.Time) ([]int, []string, time.Time, error) {
+	read, err := conn.Read(buf)
+	firstByte := now()
+
+	for {
+		statuses, lines := parseStatusCodes(buf[:read])
+		if len(statuses) >= n || err != nil || read == len(buf) {
+			if len(statuses) > n {
+				statuses, lines = statuses[:n], lines[:n]
+			}
+			hashes := make([]string, len(lines))
+			for i, line := range lines {
+				hashes[i] = hashResponseHead(line)
+			}
+			if len(statuses) == n {
+				err = nil
+			}
+			return statuses, hashes, firstByte, err
+		}
+		conn.SetReadDeadline(time.Now().Add(StatusReadTimeout))
+		var m int
+		m, err = conn.Read(buf[read:])
+		read += m
+	}
+}
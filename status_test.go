@@ -0,0 +1,53 @@
+package mcgo
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStatusCode(t *testing.T) {
+	cases := map[string]int{
+		"HTTP/1.1 200 OK\r\n\r\n":                                    200,
+		"\r\n0\r\n\r\nHTTP/1.1 403 Forbidden\r\n":                    403,
+		"HTTP/1.1 100 Continue\r\n\r\nHTTP/1.1 429 Too Many\r\n\r\n": 429,
+	}
+	for raw, expected := range cases {
+		if status, ok := parseStatusCode([]byte(raw)); !ok || status != expected {
+			t.Fatalf("got %v, %v | expected %v from %q", status, ok, expected, raw)
+		}
+	}
+
+	for _, raw := range []string{"", "HTTP/1.1 100 Continue\r\n\r\n", "HTTP/1.1 2", "garbage"} {
+		if status, ok := parseStatusCode([]byte(raw)); ok {
+			t.Fatalf("got %v | expected no status from %q", status, raw)
+		}
+	}
+}
+
+func TestReadStatus(t *testing.T) {
+	client, server := net.Pipe()
+	go func() {
+		server.Write([]byte("HTTP/1.1 100 Continue\r\n\r\n"))
+		server.Write([]byte("HTTP/1.1 200 OK\r\n\r\n"))
+	}()
+
+	status, _, err := readStatus(client, make([]byte, 4096), time.Now)
+	if err != nil || status != 200 {
+		t.Fatalf("err: %v | status: %v | expected the status after the interim response", err, status)
+	}
+
+	client, server = net.Pipe()
+	go func() {
+		server.Write([]byte(strings.Repeat("x", 600)))
+		server.Close()
+	}()
+
+	_, _, err = readStatus(client, make([]byte, 4096), time.Now)
+	var parseErr *StatusParseError
+	if !errors.As(err, &parseErr) || len(parseErr.Raw) != 512 {
+		t.Fatalf("err: %v | expected a StatusParseError with the first 512 bytes", err)
+	}
+}
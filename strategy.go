@@ -0,0 +1,148 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Named, reusable snipe settings. DropTargets refer to one by name, so a library of strategies is kept instead of the numbers being
+// repeated for every drop.
+type Strategy struct {
+	Name    string
+	Burst   BurstOptions // timing, burst size and transport mode (pipelining, outlier replacement)
+	Proxies []string     // proxy urls the drop's accounts are spread over by ApplyProxies, none keeps their clients
+}
+
+// strategies by name
+type Strategies map[string]Strategy
+
+// a strategy as written in a strategies file, durations as strings like "50ms"
+type strategyJSON struct {
+	Requests        int             `json:"requests"`
+	Spread          string          `json:"spread"`
+	Offset          string          `json:"offset"`
+	Stagger         StaggerStrategy `json:"stagger"`
+	Pipeline        bool            `json:"pipeline"`
+	ExcludeOutliers bool            `json:"excludeOutliers"`
+	OutlierFactor   float64         `json:"outlierFactor"`
+	MaxLatency      string          `json:"maxLatency"`
+	MaxJitter       string          `json:"maxJitter"`
+	Seed            *int64          `json:"seed"`
+	Proxies         []string        `json:"proxies"`
+}
+
+func parseStrategyDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// Reads strategies from a json object of strategies by name, e.g.
+//
+//	{"eu-tight": {"requests": 3, "spread": "40ms", "offset": "-15ms", "stagger": "gaussian", "proxies": ["http://eu1:8080"]}}
+func LoadStrategies(r io.Reader) (Strategies, error) {
+	var raw map[string]strategyJSON
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	strategies := Strategies{}
+	for name, s := range raw {
+		strategy := Strategy{
+			Name:    name,
+			Proxies: s.Proxies,
+			Burst: BurstOptions{
+				Requests:        s.Requests,
+				Strategy:        s.Stagger,
+				Pipeline:        s.Pipeline,
+				ExcludeOutliers: s.ExcludeOutliers,
+				OutlierFactor:   s.OutlierFactor,
+				Seed:            s.Seed,
+			},
+		}
+		for _, d := range []struct {
+			field string
+			value string
+			into  *time.Duration
+		}{
+			{"spread", s.Spread, &strategy.Burst.Spread},
+			{"offset", s.Offset, &strategy.Burst.Offset},
+			{"maxLatency", s.MaxLatency, &strategy.Burst.MaxLatency},
+			{"maxJitter", s.MaxJitter, &strategy.Burst.MaxJitter},
+		} {
+			var err error
+			if *d.into, err = parseStrategyDuration(d.value); err != nil {
+				return nil, fmt.Errorf("strategy %q: %v: %w", name, d.field, err)
+			}
+		}
+		for _, proxy := range s.Proxies {
+			if _, err := proxyClient(proxy); err != nil {
+				return nil, fmt.Errorf("strategy %q: %w", name, err)
+			}
+		}
+		strategies[name] = strategy
+	}
+	return strategies, nil
+}
+
+// names of the strategies, sorted
+func (s Strategies) Names() []string {
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// returns the strategy target refers to
+func (s Strategies) For(target DropTarget) (Strategy, error) {
+	strategy, ok := s[target.Strategy]
+	if !ok {
+		return Strategy{}, fmt.Errorf("drop of %v refers to unknown strategy %q", target.Username, target.Strategy)
+	}
+	return strategy, nil
+}
+
+// Sends the accounts through the strategy's proxies, round robin. Each account keeps its Client's settings (ReadOnly, Budget,
+// Clock) with the transport swapped for the proxy's, and gets a Dialer for the proxy. Accounts on the same proxy and Client share one.
+func (s Strategy) ApplyProxies(accounts []*MCaccount) error {
+	if len(s.Proxies) == 0 {
+		return nil
+	}
+	transports := make([]*http.Client, len(s.Proxies))
+	dialers := make([]Dialer, len(s.Proxies))
+	for i, proxy := range s.Proxies {
+		client, err := proxyClient(proxy)
+		if err != nil {
+			return err
+		}
+		transports[i] = client.HTTP
+		dialers[i], _ = NewProxyDialer(proxy)
+	}
+
+	type route struct {
+		proxy  int
+		client *Client
+	}
+	clients := map[route]*Client{}
+	for i, account := range accounts {
+		r := route{proxy: i % len(s.Proxies), client: account.Client}
+		client, ok := clients[r]
+		if !ok {
+			copied := *account.client()
+			copied.HTTP = transports[r.proxy]
+			client = &copied
+			clients[r] = client
+		}
+		account.Proxy = s.Proxies[r.proxy]
+		account.Client = client
+		account.Dialer = dialers[r.proxy]
+	}
+	return nil
+}
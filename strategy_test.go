@@ -0,0 +1,57 @@
+package mcgo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadStrategies(t *testing.T) {
+	strategies, err := LoadStrategies(strings.NewReader(`{
+		"eu-tight": {"requests": 3, "spread": "40ms", "offset": "-15ms", "stagger": "gaussian", "maxJitter": "10ms", "proxies": ["http://eu1:8080", "http://eu2:8080"]},
+		"pipelined": {"requests": 6, "pipeline": true, "seed": 0}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := strategies.Names(); !reflect.DeepEqual(names, []string{"eu-tight", "pipelined"}) {
+		t.Fatalf("names: %v | expected both strategies", names)
+	}
+
+	eu, err := strategies.For(DropTarget{Username: "Target", Strategy: "eu-tight"})
+	want := BurstOptions{Requests: 3, Spread: time.Millisecond * 40, Offset: -time.Millisecond * 15, Strategy: StaggerGaussian, MaxJitter: time.Millisecond * 10}
+	if err != nil || !reflect.DeepEqual(eu.Burst, want) {
+		t.Fatalf("err: %v | burst: %+v | expected %+v", err, eu.Burst, want)
+	}
+	if pipelined, _ := strategies.For(DropTarget{Username: "Target", Strategy: "pipelined"}); pipelined.Burst.Seed == nil || *pipelined.Burst.Seed != 0 {
+		t.Fatalf("seed: %v | expected an explicit seed of 0 to be kept", pipelined.Burst.Seed)
+	}
+	if _, err := strategies.For(DropTarget{Username: "Target", Strategy: "missing"}); err == nil {
+		t.Fatal("expected an unknown strategy to be an error")
+	}
+
+	accounts := []*MCaccount{{}, {}, {}}
+	if err := eu.ApplyProxies(accounts); err != nil {
+		t.Fatal(err)
+	}
+	if accounts[0].Proxy != "http://eu1:8080" || accounts[1].Proxy != "http://eu2:8080" || accounts[2].Client != accounts[0].Client {
+		t.Fatalf("proxies: %v, %v, %v | expected round robin", accounts[0].Proxy, accounts[1].Proxy, accounts[2].Proxy)
+	}
+	if accounts[0].Dialer == nil || accounts[2].Dialer != accounts[0].Dialer || accounts[1].Dialer == accounts[0].Dialer {
+		t.Fatal("expected a dialer per proxy")
+	}
+
+	budget, _ := NewRequestBudget(10, "")
+	limited := &MCaccount{Client: &Client{HTTP: DefaultClient.HTTP, ReadOnly: true, Budget: budget}}
+	if err := eu.ApplyProxies([]*MCaccount{limited}); err != nil {
+		t.Fatal(err)
+	}
+	if !limited.Client.ReadOnly || limited.Client.Budget != budget || limited.Client.HTTP == DefaultClient.HTTP {
+		t.Fatalf("client: %+v | expected the proxy transport with ReadOnly and Budget kept", limited.Client)
+	}
+
+	if _, err := LoadStrategies(strings.NewReader(`{"bad": {"spread": "soon"}}`)); err == nil || !strings.Contains(err.Error(), "spread") {
+		t.Fatalf("err: %v | expected an invalid duration to name the field", err)
+	}
+}
@@ -0,0 +1,229 @@
+package mcgo
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const recentResultsSize = 256
+
+var (
+	recentResultsMu sync.Mutex
+	recentResults   []NameChangeReturn
+)
+
+func recordResult(ret NameChangeReturn) {
+	ret.Account = MCaccount{Email: ret.Account.Email, Username: ret.Account.Username, UUID: ret.Account.UUID, Type: ret.Account.Type}
+
+	recentResultsMu.Lock()
+	recentResults = append(recentResults, ret)
+	if len(recentResults) > recentResultsSize {
+		recentResults = recentResults[len(recentResults)-recentResultsSize:]
+	}
+	recentResultsMu.Unlock()
+}
+
+// drops the recorded attempts of the account with email or uuid, returning how many there were
+func forgetResults(email, uuid string) int {
+	recentResultsMu.Lock()
+	defer recentResultsMu.Unlock()
+
+	var kept []NameChangeReturn
+	for _, ret := range recentResults {
+		if email != "" && strings.EqualFold(ret.Account.Email, email) || uuid != "" && strings.EqualFold(ret.Account.UUID, uuid) {
+			continue
+		}
+		kept = append(kept, ret)
+	}
+	forgot := len(recentResults) - len(kept)
+	recentResults = kept
+	return forgot
+}
+
+// returns the most recent name change attempts of this process (up to 256), oldest first. Secrets are stripped from their Account.
+func RecentResults() []NameChangeReturn {
+	recentResultsMu.Lock()
+	defer recentResultsMu.Unlock()
+	return append([]NameChangeReturn(nil), recentResults...)
+}
+
+// What goes into a support bundle. Results defaults to RecentResults().
+type SupportBundle struct {
+	Accounts []*MCaccount
+	Results  []NameChangeReturn
+	Notes    string
+}
+
+type bundleAccount struct {
+	Email           string  `json:"email"`
+	Type            AccType `json:"type"`
+	Username        string  `json:"username"`
+	UUID            string  `json:"uuid"`
+	Authenticated   bool    `json:"authenticated"`
+	HasPassword     bool    `json:"hasPassword"`
+	HasBearer       bool    `json:"hasBearer"`
+	SecurityAnswers int     `json:"securityAnswers"`
+}
+
+type latencyStats struct {
+	Samples int           `json:"samples"`
+	Min     time.Duration `json:"min"`
+	Median  time.Duration `json:"median"`
+	Max     time.Duration `json:"max"`
+	// number of results per status code, 0 counts attempts that got none
+	Statuses map[int]int `json:"statuses"`
+}
+
+type versionInfo struct {
+	Go      string `json:"go"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Module  string `json:"module"`
+	Created string `json:"created"`
+}
+
+// keeps the first two characters of the local part, so accounts stay distinguishable without exposing the address
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		if len(email) <= 2 {
+			return email
+		}
+		return email[:2] + "***"
+	}
+	local := email[:at]
+	if len(local) > 2 {
+		local = local[:2]
+	}
+	return local + "***" + email[at:]
+}
+
+func bundleLatency(results []NameChangeReturn) latencyStats {
+	stats := latencyStats{Statuses: map[int]int{}}
+	var latencies []time.Duration
+	for _, r := range results {
+		stats.Statuses[r.StatusCode]++
+		if !r.SendTime.IsZero() && !r.ReceiveTime.IsZero() {
+			latencies = append(latencies, r.ReceiveTime.Sub(r.SendTime))
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	stats.Samples = len(latencies)
+	if len(latencies) > 0 {
+		stats.Min = latencies[0]
+		stats.Median = latencies[len(latencies)/2]
+		stats.Max = latencies[len(latencies)-1]
+	}
+	return stats
+}
+
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Path == "github.com/kqzz/mcgo" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/kqzz/mcgo" {
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
+// Writes a zip for bug reports: sanitized accounts (no passwords, bearers or answers) with their Lint diagnostics,
+// a Chrome trace of the name change results, latency stats and version info.
+func GenerateSupportBundle(w io.Writer, bundle SupportBundle) error {
+	results := bundle.Results
+	if results == nil {
+		results = RecentResults()
+	}
+	// results carry whole accounts, strip them down like the accounts file
+	sanitized := make([]NameChangeReturn, len(results))
+	for i, r := range results {
+		r.Account = MCaccount{Email: maskEmail(r.Account.Email), Username: r.Account.Username, UUID: r.Account.UUID, Type: r.Account.Type}
+		sanitized[i] = r
+	}
+
+	accounts := []bundleAccount{}
+	for _, account := range bundle.Accounts {
+		accounts = append(accounts, bundleAccount{
+			Email:           maskEmail(account.Email),
+			Type:            account.Type,
+			Username:        account.Username,
+			UUID:            account.UUID,
+			Authenticated:   account.Authenticated,
+			HasPassword:     account.Password != "",
+			HasBearer:       account.Bearer != "",
+			SecurityAnswers: account.securityAnswerCount(),
+		})
+	}
+
+	diags := Lint(bundle.Accounts)
+	for i := range diags {
+		diags[i].Email = maskEmail(diags[i].Email)
+	}
+
+	zw := zip.NewWriter(w)
+
+	writeJSONFile := func(name string, v interface{}) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	if err := writeJSONFile("accounts.json", accounts); err != nil {
+		return err
+	}
+	if err := writeJSONFile("lint.json", diags); err != nil {
+		return err
+	}
+	if err := writeJSONFile("results.json", sanitized); err != nil {
+		return err
+	}
+	if err := writeJSONFile("latency.json", bundleLatency(sanitized)); err != nil {
+		return err
+	}
+	if err := writeJSONFile("version.json", versionInfo{
+		Go:      runtime.Version(),
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+		Module:  moduleVersion(),
+		Created: time.Now().UTC().Format(TimeFormat),
+	}); err != nil {
+		return err
+	}
+
+	f, err := zw.Create("trace.json")
+	if err != nil {
+		return err
+	}
+	if err := ExportTrace(f, sanitized); err != nil {
+		return err
+	}
+
+	if bundle.Notes != "" {
+		f, err := zw.Create("notes.txt")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, bundle.Notes); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
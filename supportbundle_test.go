@@ -0,0 +1,49 @@
+package mcgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateSupportBundle(t *testing.T) {
+	now := time.Now()
+	accounts := []*MCaccount{{Email: "someone@example.com", Password: "hunter2", Bearer: "secret-bearer", Type: Mj}}
+	results := []NameChangeReturn{{
+		Account:     *accounts[0],
+		StatusCode:  403,
+		DialStart:   now,
+		SendTime:    now.Add(time.Second),
+		ReceiveTime: now.Add(time.Second + time.Millisecond*80),
+	}}
+
+	var buf bytes.Buffer
+	if err := GenerateSupportBundle(&buf, SupportBundle{Accounts: accounts, Results: results, Notes: "missed the drop"}); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		rc, _ := f.Open()
+		content, _ := ioutil.ReadAll(rc)
+		rc.Close()
+		for _, secret := range []string{"hunter2", "secret-bearer", "someone@"} {
+			if strings.Contains(string(content), secret) {
+				t.Fatalf("%v leaks %q", f.Name, secret)
+			}
+		}
+	}
+
+	if strings.Join(names, ",") != "accounts.json,lint.json,results.json,latency.json,version.json,trace.json,notes.txt" {
+		t.Fatalf("bundle files: %v", names)
+	}
+}
@@ -0,0 +1,106 @@
+package mcgo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+)
+
+// Tags set by CheckAndTag. They are replaced on every check, other tags are left alone.
+const (
+	TagPrename    = "prename"     // owns the game, has no profile yet
+	TagNoMC       = "no-mc"       // doesn't own the game
+	TagBanned     = "banned"      // banned from at least one scope
+	TagNameChange = "name-change" // can change its name now
+	// cooldown-<days>d: can change its name again in that many days (rounded up)
+	tagCooldownPrefix = "cooldown-"
+)
+
+var cooldownTagRegex = regexp.MustCompile(`^` + tagCooldownPrefix + `\d+d$`)
+
+// returns the tags describing caps, cooldowns are counted from now
+func AutoTags(caps Capabilities, now time.Time) []string {
+	if !caps.OwnsGame {
+		return []string{TagNoMC}
+	}
+
+	var tags []string
+	if caps.CanCreateProfile {
+		tags = append(tags, TagPrename)
+	}
+	if caps.Banned {
+		tags = append(tags, TagBanned)
+	}
+	if caps.CanChangeName {
+		tags = append(tags, TagNameChange)
+	} else if !caps.NameChangeAllowedAt.IsZero() {
+		days := int(math.Ceil(caps.NameChangeAllowedAt.Sub(now).Hours() / 24))
+		if days < 1 {
+			days = 1
+		}
+		tags = append(tags, fmt.Sprintf("%v%vd", tagCooldownPrefix, days))
+	}
+	return tags
+}
+
+func isAutoTag(tag string) bool {
+	switch tag {
+	case TagPrename, TagNoMC, TagBanned, TagNameChange:
+		return true
+	}
+	return cooldownTagRegex.MatchString(tag)
+}
+
+// replaces the automatic tags of the account with the ones for caps
+func (account *MCaccount) applyAutoTags(caps Capabilities, now time.Time) {
+	var tags []string
+	for _, tag := range account.Tags {
+		if !isAutoTag(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	account.Tags = append(tags, AutoTags(caps, now)...)
+}
+
+func (account *MCaccount) HasTag(tag string) bool {
+	for _, t := range account.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs CheckAll, tagging every account that was checked successfully with AutoTags. Accounts whose check failed keep their tags.
+func CheckAndTag(accounts []*MCaccount, mode BulkMode) ([]Capabilities, error) {
+	caps := make([]Capabilities, len(accounts))
+	err := ForEach(accounts, mode, func(i int, account *MCaccount) error {
+		var err error
+		caps[i], err = account.Capabilities()
+		if err != nil {
+			return err
+		}
+		account.applyAutoTags(caps[i], account.now())
+		return nil
+	})
+	return caps, err
+}
+
+// returns the accounts that have every one of tags
+func Tagged(accounts []*MCaccount, tags ...string) []*MCaccount {
+	var tagged []*MCaccount
+	for _, account := range accounts {
+		hasAll := true
+		for _, tag := range tags {
+			if !account.HasTag(tag) {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			tagged = append(tagged, account)
+		}
+	}
+	return tagged
+}
@@ -0,0 +1,46 @@
+package mcgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestAutoTags(t *testing.T) {
+	now := time.Now()
+	if tags := AutoTags(Capabilities{}, now); len(tags) != 1 || tags[0] != TagNoMC {
+		t.Fatalf("tags: %v | expected no-mc", tags)
+	}
+	caps := Capabilities{OwnsGame: true, HasProfile: true, Banned: true, NameChangeAllowedAt: now.Add(time.Hour * 156)}
+	if tags := AutoTags(caps, now); len(tags) != 2 || tags[0] != TagBanned || tags[1] != "cooldown-7d" {
+		t.Fatalf("tags: %v | expected banned and cooldown-7d", tags)
+	}
+}
+
+func TestIsAutoTag(t *testing.T) {
+	for tag, want := range map[string]bool{"cooldown-7d": true, "cooldown-30d": true, TagPrename: true, "cooldown-tracked": false, "cooldown-d": false, "cooldown-7days": false, "pool-a": false} {
+		if got := isAutoTag(tag); got != want {
+			t.Fatalf("tag: %v | auto: %v | expected %v", tag, got, want)
+		}
+	}
+}
+
+func TestCheckAndTag(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	prename := newFakeAccount(srv, mcgotest.Account{OwnsGame: true})
+	prename.Tags = []string{"pool-a", TagNoMC}
+	noMC := newFakeAccount(srv, mcgotest.Account{})
+
+	if _, err := CheckAndTag([]*MCaccount{prename, noMC}, SoftFail); err != nil {
+		t.Fatal(err)
+	}
+	if !prename.HasTag("pool-a") || !prename.HasTag(TagPrename) || prename.HasTag(TagNoMC) {
+		t.Fatalf("tags: %v | expected pool-a kept, no-mc replaced by prename", prename.Tags)
+	}
+	if tagged := Tagged([]*MCaccount{prename, noMC}, TagNoMC); len(tagged) != 1 || tagged[0] != noMC {
+		t.Fatalf("tagged: %v | expected only the account without the game", tagged)
+	}
+}
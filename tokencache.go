@@ -0,0 +1,208 @@
+package mcgo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrCacheDecrypt = errors.New("token cache can't be decrypted, wrong passphrase or corrupted file")
+
+// iterations of the passphrase key derivation, slows down guessing the passphrase of a stolen cache file
+const cacheKeyIterations = 100000
+
+// tokens of one account kept in the cache
+type cachedTokens struct {
+	Bearer         string    `json:"bearer"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	ClientToken    string    `json:"clientToken,omitempty"`
+	MsRefreshToken string    `json:"msRefreshToken,omitempty"`
+	MsClientID     string    `json:"msClientId,omitempty"`
+	XblToken       string    `json:"xblToken,omitempty"`
+	XblUserHash    string    `json:"xblUserHash,omitempty"`
+	UUID           string    `json:"uuid,omitempty"`
+	Username       string    `json:"username,omitempty"`
+}
+
+type cacheFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"` // AES-256-GCM sealed json of the tokens by lowercase email
+}
+
+// PBKDF2-HMAC-SHA256 with a single 32 byte block, the key for AES-256
+func pbkdf2(passphrase string, salt []byte, iterations int) []byte {
+	mac := hmac.New(sha256.New, []byte(passphrase))
+	mac.Write(salt)
+	mac.Write([]byte{0, 0, 0, 1})
+	u := mac.Sum(nil)
+	key := append([]byte(nil), u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return key
+}
+
+func cacheCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2(passphrase, salt, cacheKeyIterations))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Saves the bearers, refresh tokens and client tokens of accounts to path, encrypted with a key derived from passphrase,
+// so a restarted program can LoadCache them instead of authenticating every account again. Accounts are keyed by email, accounts
+// without one (e.g. device code logins) by UUID. Accounts with neither can't be loaded back, they're skipped and counted in the error.
+func SaveCache(path, passphrase string, accounts []*MCaccount) error {
+	tokens := map[string]cachedTokens{}
+	skipped := 0
+	for _, account := range accounts {
+		lock := account.authLock()
+		lock.RLock()
+		key := cacheKey(account)
+		if key != "" {
+			tokens[key] = cachedTokens{
+				Bearer:         account.Bearer,
+				ExpiresAt:      account.ExpiresAt,
+				ClientToken:    account.ClientToken,
+				MsRefreshToken: account.MsRefreshToken,
+				MsClientID:     account.MsClientID,
+				XblToken:       account.XblToken,
+				XblUserHash:    account.XblUserHash,
+				UUID:           account.UUID,
+				Username:       account.Username,
+			}
+		}
+		lock.RUnlock()
+		if key == "" {
+			skipped++
+		}
+	}
+	if err := writeCache(path, passphrase, tokens); err != nil {
+		return err
+	}
+	if skipped > 0 {
+		return fmt.Errorf("%v accounts have no email or uuid to cache their tokens under and were skipped", skipped)
+	}
+	return nil
+}
+
+// key the tokens of account are cached under, its email or, without one, its uuid. Empty if it has neither.
+func cacheKey(account *MCaccount) string {
+	if account.Email != "" {
+		return strings.ToLower(account.Email)
+	}
+	if account.UUID != "" {
+		return "uuid:" + strings.ToLower(account.UUID)
+	}
+	return ""
+}
+
+// encrypts tokens with a new salt and nonce and replaces the file at path
+func writeCache(path, passphrase string, tokens map[string]cachedTokens) error {
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	file := cacheFile{Salt: make([]byte, 16)}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return err
+	}
+	aead, err := cacheCipher(passphrase, file.Salt)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Data = aead.Seal(nil, file.Nonce, plain, file.Salt)
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// decrypts the cache at path, a missing file has no tokens
+func readCache(path, passphrase string) (map[string]cachedTokens, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	aead, err := cacheCipher(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, ErrCacheDecrypt
+	}
+	plain, err := aead.Open(nil, file.Nonce, file.Data, file.Salt)
+	if err != nil {
+		return nil, ErrCacheDecrypt
+	}
+	var tokens map[string]cachedTokens
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Restores the tokens SaveCache wrote to path into the accounts with the same email (or uuid), accounts not in the cache are left alone.
+// An account whose cached bearer hasn't expired is Authenticated again, one with an expired bearer keeps its refresh tokens
+// for KeepAlive or PreAuthenticate. A missing file loads nothing, a wrong passphrase returns ErrCacheDecrypt.
+func LoadCache(path, passphrase string, accounts []*MCaccount) error {
+	tokens, err := readCache(path, passphrase)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		key := cacheKey(account)
+		cached, ok := tokens[key]
+		if key == "" || !ok {
+			continue
+		}
+		from := account.snapshot()
+		from.Bearer = cached.Bearer
+		from.ExpiresAt = cached.ExpiresAt
+		from.ClientToken = cached.ClientToken
+		from.MsRefreshToken = cached.MsRefreshToken
+		from.MsClientID = cached.MsClientID
+		from.XblToken = cached.XblToken
+		from.XblUserHash = cached.XblUserHash
+		from.UUID = cached.UUID
+		from.Username = cached.Username
+		from.Authenticated = cached.Bearer != "" && (cached.ExpiresAt.IsZero() || account.now().Before(cached.ExpiresAt))
+		account.applyAuth(&from)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package mcgo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.cache")
+	bearer := testBearer(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(time.Hour).Unix()))
+	saved := &MCaccount{Email: "Cache@example.com", Bearer: bearer, ClientToken: "client", MsRefreshToken: "refresh", MsClientID: "app", Username: "Cached", UUID: "uuid"}
+	saved.updateExpiry()
+	expired := &MCaccount{Email: "old@example.com", Bearer: "old", ExpiresAt: time.Now().Add(-time.Minute), MsRefreshToken: "old-refresh"}
+
+	if err := SaveCache(path, "hunter2", []*MCaccount{saved, expired}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil || strings.Contains(string(data), "refresh") || strings.Contains(string(data), "Cached") {
+		t.Fatalf("err: %v | expected the tokens to be encrypted on disk", err)
+	}
+
+	restarted := &MCaccount{Email: "cache@example.com"}
+	old := &MCaccount{Email: "old@example.com"}
+	other := &MCaccount{Email: "other@example.com", Bearer: "own"}
+	if err := LoadCache(path, "wrong", []*MCaccount{restarted}); err != ErrCacheDecrypt || restarted.Bearer != "" {
+		t.Fatalf("err: %v | expected a wrong passphrase to load nothing", err)
+	}
+	if err := LoadCache(path, "hunter2", []*MCaccount{restarted, old, other}); err != nil {
+		t.Fatal(err)
+	}
+	if restarted.Bearer != bearer || restarted.MsRefreshToken != "refresh" || restarted.MsClientID != "app" || restarted.Username != "Cached" || !restarted.Authenticated {
+		t.Fatalf("account: %+v | expected the cached tokens to be restored", restarted)
+	}
+	if old.MsRefreshToken != "old-refresh" || old.Authenticated {
+		t.Fatalf("account: %+v | expected the refresh token but no authentication for an expired bearer", old)
+	}
+	if other.Bearer != "own" {
+		t.Fatal("expected accounts missing from the cache to be left alone")
+	}
+
+	if err := LoadCache(filepath.Join(t.TempDir(), "missing"), "hunter2", []*MCaccount{other}); err != nil {
+		t.Fatalf("err: %v | expected a missing cache to load nothing", err)
+	}
+}
+
+func TestTokenCacheWithoutEmail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.cache")
+	device := &MCaccount{Bearer: "device", MsRefreshToken: "device-refresh", UUID: "ABC123", Username: "Device"}
+	anonymous := &MCaccount{Bearer: "anonymous"}
+
+	if err := SaveCache(path, "hunter2", []*MCaccount{device, anonymous}); err == nil || !strings.Contains(err.Error(), "1 accounts") {
+		t.Fatalf("err: %v | expected the account without email or uuid to be reported", err)
+	}
+
+	restarted := &MCaccount{UUID: "abc123"}
+	if err := LoadCache(path, "hunter2", []*MCaccount{restarted, {}}); err != nil || restarted.MsRefreshToken != "device-refresh" || restarted.Username != "Device" {
+		t.Fatalf("err: %v | account: %+v | expected the tokens to be found by uuid", err, restarted)
+	}
+}
+
+func TestPbkdf2(t *testing.T) {
+	// first blocks of the PBKDF2-HMAC-SHA256 vectors of RFC 7914 and RFC 6070 (run with sha256)
+	for _, v := range []struct {
+		passphrase, salt string
+		iterations       int
+		key              string
+	}{
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc"},
+		{"password", "salt", 1, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 4096, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+	} {
+		if got := hex.EncodeToString(pbkdf2(v.passphrase, []byte(v.salt), v.iterations)); got != v.key {
+			t.Fatalf("got %v | expected %v for %+v", got, v.key, v)
+		}
+	}
+}
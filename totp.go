@@ -0,0 +1,83 @@
+package mcgo
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Returns the 6 digit code (RFC 6238: SHA1, 30 second steps) an authenticator app set up with the base32 secret shows at t.
+func TOTP(secret string, t time.Time) (string, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
+
+// code for a 2fa challenge from the options
+func (o MsAuthOptions) twoFactorCode(now time.Time) (string, error) {
+	if o.TOTPCode != nil {
+		return o.TOTPCode()
+	}
+	if o.TOTPSecret != "" {
+		return TOTP(o.TOTPSecret, now)
+	}
+	return "", errors.New("2fa is enabled, set TOTPSecret or TOTPCode in MsAuthOptions")
+}
+
+// reports whether a page of the password login asks for a one time code
+func isOtcChallenge(page string) bool {
+	return strings.Contains(page, "Help us protect your account") || strings.Contains(page, `name="otc"`)
+}
+
+// kinds of one time codes the login form takes
+const (
+	otcEmail         = "18" // sent by email
+	otcAuthenticator = "19" // from an authenticator app
+)
+
+// answers the one time code challenge on page, returning the page it leads to
+func postOtc(client *http.Client, page []byte, email string, code string, otcType string) (string, error) {
+	ppft, urlPost, err := loginForm(page)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.PostForm(urlPost, url.Values{
+		"login": {email},
+		"otc":   {code},
+		"type":  {otcType},
+		"AddTD": {"true"},
+		"PPFT":  {ppft},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(respBytes), nil
+}
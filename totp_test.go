@@ -0,0 +1,48 @@
+package mcgo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 test secret "12345678901234567890", codes truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	for unix, expected := range map[int64]string{59: "287082", 1111111109: "081804", 2000000000: "279037"} {
+		code, err := TOTP(secret, time.Unix(unix, 0))
+		if err != nil || code != expected {
+			t.Fatalf("err: %v | code at %v: %v | expected: %v", err, unix, code, expected)
+		}
+	}
+
+	if _, err := (MsAuthOptions{}).twoFactorCode(time.Now()); err == nil {
+		t.Fatal("expected an error without a secret or callback")
+	}
+	if code, err := (MsAuthOptions{TOTPCode: func() (string, error) { return "123456", nil }}).twoFactorCode(time.Now()); err != nil || code != "123456" {
+		t.Fatalf("err: %v | code: %v | expected the callback's code", err, code)
+	}
+}
+
+func TestPostOtc(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("otc") != "123456" || r.Form.Get("PPFT") != "flowtoken" || r.Form.Get("login") != "2fa@example.com" {
+			fmt.Fprint(w, `<input name="otc">`)
+			return
+		}
+		fmt.Fprint(w, "signed in")
+	}))
+	defer srv.Close()
+
+	challenge := []byte(fmt.Sprintf(`sFTTag:'<input type="hidden" name="PPFT" value="flowtoken"/>', urlPost:'%v', <input name="otc">`, srv.URL))
+	if !isOtcChallenge(string(challenge)) {
+		t.Fatal("expected the page to be a 2fa challenge")
+	}
+	page, err := postOtc(srv.Client(), challenge, "2fa@example.com", "123456", otcAuthenticator)
+	if err != nil || isOtcChallenge(page) {
+		t.Fatalf("err: %v | page: %v | expected the code to be accepted", err, page)
+	}
+}
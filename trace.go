@@ -0,0 +1,68 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+type traceEvent struct {
+	Name  string                 `json:"name"`
+	Phase string                 `json:"ph"`
+	Ts    float64                `json:"ts"`
+	Dur   float64                `json:"dur,omitempty"`
+	Pid   int                    `json:"pid"`
+	Tid   int                    `json:"tid"`
+	Scope string                 `json:"s,omitempty"`
+	Args  map[string]interface{} `json:"args,omitempty"`
+}
+
+type traceFile struct {
+	TraceEvents     []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// Writes the timings of name change attempts as Chrome trace JSON, viewable in chrome://tracing or Perfetto.
+// Each result is shown as its own thread: handshake, prewarm (connection held open with the partial payload written), and the response wait.
+func ExportTrace(w io.Writer, results []NameChangeReturn) error {
+	var origin time.Time
+	for _, r := range results {
+		if !r.DialStart.IsZero() && (origin.IsZero() || r.DialStart.Before(origin)) {
+			origin = r.DialStart
+		}
+	}
+
+	ts := func(t time.Time) float64 {
+		return float64(t.Sub(origin)) / float64(time.Microsecond)
+	}
+
+	trace := traceFile{TraceEvents: []traceEvent{}, DisplayTimeUnit: "ms"}
+
+	for i, r := range results {
+		args := map[string]interface{}{"username": r.Username, "status": r.StatusCode}
+
+		span := func(name string, start, end time.Time) {
+			if start.IsZero() || end.IsZero() {
+				return
+			}
+			trace.TraceEvents = append(trace.TraceEvents, traceEvent{Name: name, Phase: "X", Ts: ts(start), Dur: ts(end) - ts(start), Pid: 1, Tid: i, Args: args})
+		}
+		instant := func(name string, at time.Time) {
+			if at.IsZero() {
+				return
+			}
+			trace.TraceEvents = append(trace.TraceEvents, traceEvent{Name: name, Phase: "i", Ts: ts(at), Pid: 1, Tid: i, Scope: "t", Args: args})
+		}
+
+		span("handshake", r.DialStart, r.DialEnd)
+		instant("partial write", r.PartialWriteTime)
+		span("prewarm", r.PartialWriteTime, r.SendTime)
+		span("scheduler slip", r.ScheduledTime, r.WakeTime)
+		instant("final write", r.SendTime)
+		span("response", r.SendTime, r.ReceiveTime)
+		instant("first byte", r.ReceiveTime)
+	}
+
+	return json.NewEncoder(w).Encode(trace)
+}
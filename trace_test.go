@@ -0,0 +1,43 @@
+package mcgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestExportTrace(t *testing.T) {
+	start := time.Now()
+	results := []NameChangeReturn{{
+		Username:         "test",
+		StatusCode:       200,
+		DialStart:        start,
+		DialEnd:          start.Add(time.Millisecond * 40),
+		PartialWriteTime: start.Add(time.Millisecond * 41),
+		SendTime:         start.Add(time.Second),
+		ReceiveTime:      start.Add(time.Second + time.Millisecond*60),
+	}, {
+		Username:  "test",
+		DialStart: start.Add(time.Millisecond),
+	}}
+
+	var buf bytes.Buffer
+	if err := ExportTrace(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+
+	var trace traceFile
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second result never connected, so only the first contributes events
+	if len(trace.TraceEvents) != 6 {
+		t.Fatalf("events: %v | expected 6", trace.TraceEvents)
+	}
+	handshake := trace.TraceEvents[0]
+	if handshake.Name != "handshake" || handshake.Ts != 0 || handshake.Dur != 40000 {
+		t.Fatalf("handshake event: %+v", handshake)
+	}
+}
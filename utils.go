@@ -23,7 +23,7 @@ func SameErrorMessage(err, target error) bool {
 }
 
 func NameAvailability(username string) (string, error) {
-	resp, err := http.Get(fmt.Sprintf("https://api.mojang.com/user/profile/agent/minecraft/name/%v", username))
+	resp, err := DefaultClient.Get(fmt.Sprintf("https://api.mojang.com/user/profile/agent/minecraft/name/%v", username))
 
 	if err != nil {
 		return "", err
@@ -0,0 +1,62 @@
+package mcgo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Returned by ValidateBearer when the bearer can't be used anymore (expired, refreshed or invalidated).
+type BearerInvalidError struct {
+	StatusCode int
+}
+
+func (e *BearerInvalidError) Error() string {
+	return fmt.Sprintf("bearer is no longer valid (status %v)", e.StatusCode)
+}
+
+type validateReqBody struct {
+	AccessToken string `json:"accessToken"`
+	ClientToken string `json:"clientToken,omitempty"`
+}
+
+// Returns whether the current Bearer is still usable. Mojang bearers are checked with the yggdrasil validate endpoint,
+// microsoft ones with a profile request (accounts without a profile still have a valid bearer).
+// An unusable bearer returns false and a *BearerInvalidError, other errors mean the check itself failed.
+func (account *MCaccount) ValidateBearer() (bool, error) {
+	if account.Type == Mj {
+		return account.validateYggdrasil()
+	}
+
+	_, err := account.FetchProfile()
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		switch reqErr.StatusCode {
+		case 401:
+			return false, &BearerInvalidError{StatusCode: reqErr.StatusCode}
+		case 404:
+			return true, nil
+		}
+	}
+	return err == nil, err
+}
+
+func (account *MCaccount) validateYggdrasil() (bool, error) {
+	auth := account.snapshot()
+	resp, err := account.postAuthserver("validate", validateReqBody{AccessToken: auth.Bearer, ClientToken: auth.ClientToken})
+	if err != nil {
+		return false, err
+	}
+
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == 204 || resp.StatusCode == 200:
+		return true, nil
+	case resp.StatusCode == 403 || resp.StatusCode == 401:
+		return false, &BearerInvalidError{StatusCode: resp.StatusCode}
+	}
+	return false, &RequestError{
+		StatusCode: resp.StatusCode,
+		Err:        fmt.Errorf("got status %v when validating bearer", resp.Status),
+	}
+}
@@ -0,0 +1,29 @@
+package mcgo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestValidateBearer(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	for _, accType := range []AccType{Mj, MsPr} {
+		acc := newFakeAccount(srv, mcgotest.Account{Name: "Valid", OwnsGame: true})
+		acc.Type = accType
+
+		if valid, err := acc.ValidateBearer(); !valid || err != nil {
+			t.Fatalf("err: %v | expected the %v bearer to be valid", err, accType)
+		}
+
+		acc.Bearer = "revoked"
+		valid, err := acc.ValidateBearer()
+		var invalid *BearerInvalidError
+		if valid || !errors.As(err, &invalid) {
+			t.Fatalf("err: %v | expected a BearerInvalidError for the revoked %v bearer", err, accType)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package mcgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+var leetReplacements = map[rune]rune{
+	'a': '4',
+	'e': '3',
+	'i': '1',
+	'o': '0',
+	's': '5',
+	't': '7',
+}
+
+func validVariant(name string) bool {
+	if len(name) < 3 || len(name) > 16 {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns near variants of name that are different names to Mojang: underscores added around it, and leet substitutions.
+// Names differing only in capitalization are the same name, so variants are lowercase and never just a recapitalized name.
+func NameVariants(name string) []string {
+	base := strings.ToLower(name)
+	seen := map[string]bool{base: true}
+	var variants []string
+
+	add := func(v string) {
+		if !seen[v] && validVariant(v) {
+			seen[v] = true
+			variants = append(variants, v)
+		}
+	}
+
+	add(base + "_")
+	add("_" + base)
+	add("_" + base + "_")
+
+	runes := []rune(base)
+	all := make([]rune, len(runes))
+	copy(all, runes)
+	for i, c := range runes {
+		if leet, ok := leetReplacements[c]; ok {
+			single := make([]rune, len(runes))
+			copy(single, runes)
+			single[i] = leet
+			add(string(single))
+			all[i] = leet
+		}
+	}
+	add(string(all))
+
+	return variants
+}
+
+type bulkProfileResp struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Legacy bool   `json:"legacy"`
+	Demo   bool   `json:"demo"`
+}
+
+// looks up the profiles owning up to 10 names in one request, names nobody owns are left out
+func bulkLookup(client *Client, names []string) ([]bulkProfileResp, error) {
+	body, err := json.Marshal(names)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.HTTP.Post("https://api.mojang.com/profiles/minecraft", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v on bulk profile lookup", resp.Status),
+		}
+	}
+
+	var profiles []bulkProfileResp
+	if err := json.Unmarshal(respBytes, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Returns which of the names are owned by a profile, using the bulk lookup (one request per 10 names). Keys are lowercase.
+func NamesOwned(names []string) (map[string]bool, error) {
+	owned := map[string]bool{}
+	for _, name := range names {
+		owned[strings.ToLower(name)] = false
+	}
+
+	for start := 0; start < len(names); start += 10 {
+		end := start + 10
+		if end > len(names) {
+			end = len(names)
+		}
+
+		profiles, err := bulkLookup(DefaultClient, names[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, profile := range profiles {
+			owned[strings.ToLower(profile.Name)] = true
+		}
+	}
+
+	return owned, nil
+}
+
+// Returns the variants of name that nobody owns. They may still be blocked, check them with NameStatus before relying on one.
+func UnownedVariants(name string) ([]string, error) {
+	variants := NameVariants(name)
+	owned, err := NamesOwned(variants)
+	if err != nil {
+		return nil, err
+	}
+
+	var unowned []string
+	for _, v := range variants {
+		if !owned[v] {
+			unowned = append(unowned, v)
+		}
+	}
+	return unowned, nil
+}
@@ -0,0 +1,39 @@
+package mcgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestNameVariants(t *testing.T) {
+	variants := NameVariants("Test")
+	expected := []string{"test_", "_test", "_test_", "7est", "t3st", "te5t", "tes7", "7357"}
+	if fmt.Sprint(variants) != fmt.Sprint(expected) {
+		t.Fatalf("variants: %v | expected: %v", variants, expected)
+	}
+
+	for _, v := range NameVariants("sixteencharname_") {
+		if len(v) > 16 {
+			t.Fatalf("variant %v is longer than 16 characters", v)
+		}
+	}
+}
+
+func TestUnownedVariants(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	srv.AddAccount(mcgotest.Account{Name: "Test_"})
+	srv.AddAccount(mcgotest.Account{Name: "t3st"})
+
+	old := DefaultClient
+	DefaultClient = &Client{HTTP: srv.HTTPClient()}
+	defer func() { DefaultClient = old }()
+
+	unowned, err := UnownedVariants("test")
+	expected := []string{"_test", "_test_", "7est", "te5t", "tes7", "7357"}
+	if err != nil || fmt.Sprint(unowned) != fmt.Sprint(expected) {
+		t.Fatalf("err: %v | unowned: %v | expected: %v", err, unowned, expected)
+	}
+}
@@ -0,0 +1,78 @@
+package mcgo
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type VoucherStatus string
+
+const (
+	VoucherRedeemed        VoucherStatus = "redeemed"         // the code was applied to the account
+	VoucherAlreadyRedeemed VoucherStatus = "already-redeemed" // the code is real but was used before
+	VoucherInvalid         VoucherStatus = "invalid"          // no such code
+	VoucherValid           VoucherStatus = "valid"            // the code can still be redeemed, returned by CheckVoucher
+)
+
+func voucherURL(code string) string {
+	return "https://api.minecraftservices.com/productvoucher/" + url.PathEscape(strings.TrimSpace(code))
+}
+
+// Redeems a gift code or prepaid card on the account. Codes that are unknown or were already used aren't errors, they're
+// reported as VoucherInvalid and VoucherAlreadyRedeemed.
+func (account *MCaccount) RedeemCode(code string) (VoucherStatus, error) {
+	if err := account.checkWritable(); err != nil {
+		return "", err
+	}
+
+	req, err := account.AuthenticatedReq("PUT", voucherURL(code), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := account.do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < 300:
+		return VoucherRedeemed, nil
+	case resp.StatusCode == 404:
+		return VoucherInvalid, nil
+	case resp.StatusCode == 409:
+		return VoucherAlreadyRedeemed, nil
+	}
+	return "", &RequestError{
+		StatusCode: resp.StatusCode,
+		Err:        fmt.Errorf("got status %v when redeeming code", resp.Status),
+	}
+}
+
+// Checks a gift code without redeeming it, so codes can be sorted before picking the accounts they go to.
+// Returns VoucherValid, VoucherAlreadyRedeemed or VoucherInvalid.
+func (account *MCaccount) CheckVoucher(code string) (VoucherStatus, error) {
+	var voucher struct {
+		Status string `json:"status"`
+	}
+	err := account.getJSON(voucherURL(code), &voucher)
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode == 404 {
+		return VoucherInvalid, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch voucher.Status {
+	case "ACTIVE":
+		return VoucherValid, nil
+	case "REDEEMED":
+		return VoucherAlreadyRedeemed, nil
+	}
+	return "", fmt.Errorf("unknown voucher status %q", voucher.Status)
+}
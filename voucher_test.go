@@ -0,0 +1,59 @@
+package mcgo
+
+import (
+	"testing"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestRedeemCode(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	srv.AddVoucher("ABCDE-FGHIJ-KLMNO")
+
+	first := newFakeAccount(srv, mcgotest.Account{Email: "first@example.com"})
+	second := newFakeAccount(srv, mcgotest.Account{Email: "second@example.com"})
+
+	if status, err := first.RedeemCode(" abcde-fghij-klmno "); err != nil || status != VoucherRedeemed {
+		t.Fatalf("err: %v | status: %v | expected the code to be redeemed", err, status)
+	}
+	if owns, err := first.OwnsMinecraft(); err != nil || !owns {
+		t.Fatalf("err: %v | owns: %v | expected the account to own the game", err, owns)
+	}
+	if redeemedBy, _ := srv.Voucher("ABCDE-FGHIJ-KLMNO"); redeemedBy != "first@example.com" {
+		t.Fatalf("redeemed by: %q | expected the first account", redeemedBy)
+	}
+
+	if status, err := second.RedeemCode("ABCDE-FGHIJ-KLMNO"); err != nil || status != VoucherAlreadyRedeemed {
+		t.Fatalf("err: %v | status: %v | expected the code to be used up", err, status)
+	}
+	if status, err := second.RedeemCode("ZZZZZ-ZZZZZ-ZZZZZ"); err != nil || status != VoucherInvalid {
+		t.Fatalf("err: %v | status: %v | expected an unknown code to be invalid", err, status)
+	}
+
+	second.Client.ReadOnly = true
+	if _, err := second.RedeemCode("ABCDE-FGHIJ-KLMNO"); err != ErrReadOnly {
+		t.Fatalf("err: %v | expected read only clients to refuse", err)
+	}
+}
+
+func TestCheckVoucher(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+	srv.AddVoucher("FRESH-CODE")
+	srv.AddVoucher("USED-CODE")
+
+	acc := newFakeAccount(srv, mcgotest.Account{Email: "checker@example.com"})
+	if _, err := acc.RedeemCode("USED-CODE"); err != nil {
+		t.Fatal(err)
+	}
+
+	for code, want := range map[string]VoucherStatus{"FRESH-CODE": VoucherValid, "used-code": VoucherAlreadyRedeemed, "NO-SUCH-CODE": VoucherInvalid} {
+		if status, err := acc.CheckVoucher(code); err != nil || status != want {
+			t.Fatalf("err: %v | status: %v | expected %v for %v", err, status, want, code)
+		}
+	}
+	if redeemedBy, _ := srv.Voucher("FRESH-CODE"); redeemedBy != "" {
+		t.Fatalf("redeemed by: %q | expected checking not to redeem the code", redeemedBy)
+	}
+}
@@ -0,0 +1,88 @@
+package mcgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+var ErrNoXblToken = errors.New("account has no xbox live token, log in with a microsoft flow first")
+
+// Xbox identity linked to a microsoft account.
+type XboxProfile struct {
+	XUID     string
+	Gamertag string
+}
+
+type xboxProfileResponse struct {
+	ProfileUsers []struct {
+		ID       string `json:"id"`
+		Settings []struct {
+			ID    string `json:"id"`
+			Value string `json:"value"`
+		} `json:"settings"`
+	} `json:"profileUsers"`
+}
+
+// Fetches the gamertag and XUID of the xbox account from the XBL profile API, with the XBL token of the last microsoft login,
+// and fills in the account's XUID and Gamertag. (The XUID alone is also in the claims of microsoft bearers, see TokenInfo.)
+func (account *MCaccount) FetchXboxProfile() (XboxProfile, error) {
+	if account.XblToken == "" {
+		return XboxProfile{}, ErrNoXblToken
+	}
+
+	// the profile API takes XSTS tokens for xbox live itself, not the minecraftservices one
+	xsts, err := exchangeXsts(account.client().HTTP, account.XblToken, "http://xboxlive.com")
+	if err != nil {
+		return XboxProfile{}, err
+	}
+
+	req, err := http.NewRequest("GET", "https://profile.xboxlive.com/users/me/profile/settings?settings=Gamertag", nil)
+	if err != nil {
+		return XboxProfile{}, err
+	}
+	req.Header.Set("Authorization", "XBL3.0 x="+account.XblUserHash+";"+xsts.Token)
+	req.Header.Set("x-xbl-contract-version", "3")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := account.do(req)
+	if err != nil {
+		return XboxProfile{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return XboxProfile{}, &RequestError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("got status %v when requesting xbox profile", resp.Status),
+		}
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return XboxProfile{}, err
+	}
+
+	var profileResp xboxProfileResponse
+	if err := json.Unmarshal(respBytes, &profileResp); err != nil {
+		return XboxProfile{}, err
+	}
+	if len(profileResp.ProfileUsers) == 0 {
+		return XboxProfile{}, errors.New("xbox profile response has no user")
+	}
+
+	user := profileResp.ProfileUsers[0]
+	profile := XboxProfile{XUID: user.ID}
+	for _, setting := range user.Settings {
+		if setting.ID == "Gamertag" {
+			profile.Gamertag = setting.Value
+		}
+	}
+
+	account.XUID = profile.XUID
+	account.Gamertag = profile.Gamertag
+	return profile, nil
+}
@@ -0,0 +1,32 @@
+package mcgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kqzz/mcgo/mcgotest"
+)
+
+func TestFetchXboxProfile(t *testing.T) {
+	srv := mcgotest.NewServer()
+	defer srv.Close()
+
+	fake := srv.AddAccount(mcgotest.Account{Email: "xbox@example.com", Name: "Java", OwnsGame: true, XUID: "2535400000000001", Gamertag: "Bedrock"})
+	acc := &MCaccount{Type: MsPr, Client: &Client{HTTP: srv.HTTPClient()}}
+
+	if _, err := acc.FetchXboxProfile(); err != ErrNoXblToken {
+		t.Fatalf("err: %v | expected ErrNoXblToken before logging in", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if err := acc.MsDeviceCodeAuth(ctx, func(code DeviceCode) { srv.ApproveDeviceCode(code.UserCode, fake.Email) }); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := acc.FetchXboxProfile()
+	if err != nil || profile.XUID != "2535400000000001" || profile.Gamertag != "Bedrock" || acc.Gamertag != "Bedrock" || acc.XUID != profile.XUID {
+		t.Fatalf("err: %v | profile: %+v | expected the linked xbox identity", err, profile)
+	}
+}